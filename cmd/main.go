@@ -1,17 +1,28 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 	_ "github.com/zikri124/mygram-api/cmd/docs"
+	"github.com/zikri124/mygram-api/internal/config"
 	"github.com/zikri124/mygram-api/internal/handler"
 	"github.com/zikri124/mygram-api/internal/infrastructure"
 	"github.com/zikri124/mygram-api/internal/middleware"
 	"github.com/zikri124/mygram-api/internal/repository"
 	"github.com/zikri124/mygram-api/internal/router"
 	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/logging"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -30,53 +41,231 @@ import (
 // @BasePath		/
 // @schemes			http
 func main() {
-	err := godotenv.Load()
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		log.Fatalln("Cannot load env: ", err)
+		fatal("cannot load config", "error", err)
+	}
+
+	logLevel, _ := cfg.Log.ParseLevel()
+	slog.SetDefault(logging.New(os.Stdout, logLevel))
+
+	err = snowflake.SetNode(cfg.Ids.Node)
+	if err != nil {
+		fatal("invalid ids.node", "error", err)
+	}
+
+	publicid.SetSecret(cfg.Ids.PublicSecret)
+
+	if len(args) > 0 && args[0] == "migrate" {
+		runMigrate(cfg, args[1:])
+		return
 	}
 
-	g := gin.Default()
+	g := gin.New()
+	// the services get the gin context, it must resolve the request id of
+	// the request context
+	g.ContextWithFallback = true
+	g.Use(middleware.RequestId(), middleware.Recovery())
+
+	gorm := infrastructure.NewGormPostgres(cfg.Database)
+	txManager := infrastructure.NewTxManager(gorm)
+
+	storage := infrastructure.NewLocalStorage(cfg.Storage)
+
+	backgroundWorkers := newWorkers()
+
+	eventHub := infrastructure.NewEventHub(infrastructure.NewEventBroker(gorm, cfg.Database, cfg.EventBroker))
+	backgroundWorkers.GoUntilStop(func(ctx context.Context) {
+		eventHub.Run(ctx)
+	})
+
+	// the probes are mounted before the other middlewares so they are never
+	// rate limited nor fill the access logs
+	healthService := service.NewHealthService(cfg.Health)
+	healthService.Register("postgres", gorm.Ping)
+	healthHandler := handler.NewHealthHandler(healthService)
+	healthRouter := router.NewHealthRouter(g.Group(""), healthHandler)
+	healthRouter.Mount()
 
-	gorm := infrastructure.NewGormPostgres()
+	g.Use(middleware.AccessLog())
+	g.Use(middleware.CorsMiddleware(cfg.Cors))
+	g.Use(middleware.RateLimit(cfg.RateLimit))
 
-	g.Use(middleware.CorsMiddleware())
+	webhookRepo := repository.NewWebhookRepository(gorm)
+	webhookService := service.NewWebhookService(webhookRepo)
+	backgroundWorkers.Go(webhookService.RunWorker)
+
+	socialMediaRepo := repository.NewSocialMediaRepository(gorm)
+	socialMediaService := service.NewSocialMediaService(socialMediaRepo)
 
 	userRouteGroup := g.Group("/v1/users")
 	userRepo := repository.NewUserRepository(gorm)
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(userRepo, webhookService, socialMediaService, storage, cfg.Jwt)
+	backgroundWorkers.Go(userService.RunPurgeWorker)
 	userHandler := handler.NewUserHandler(userService)
 
-	auth := middleware.NewAuthorization(userService)
+	auth := middleware.NewAuthorization(userService, cfg.Jwt.Secret)
 
-	userRouter := router.NewUserRouter(userRouteGroup, userHandler, auth)
+	userRouter := router.NewUserRouter(userRouteGroup, userHandler, auth, cfg.Features.Registration)
 	userRouter.Mount()
 
+	tagRepo := repository.NewTagRepository(gorm)
+
+	notificationRepo := repository.NewNotificationRepository(gorm)
+	notificationService := service.NewNotificationService(notificationRepo, eventHub)
+
+	mentionRepo := repository.NewMentionRepository(gorm)
+	mentionService := service.NewMentionService(mentionRepo, userRepo, notificationService)
+
+	if cfg.Features.Exports {
+		exportRouteGroup := g.Group("/v1")
+		exportRepo := repository.NewExportRepository(gorm)
+		exportService := service.NewExportService(exportRepo, userRepo, storage, cfg.Storage.ExportDir)
+		backgroundWorkers.Go(exportService.RunWorker)
+		exportHandler := handler.NewExportHandler(exportService)
+		exportRouter := router.NewExportRouter(exportRouteGroup, exportHandler, auth)
+		exportRouter.Mount()
+	}
+
+	followRouteGroup := g.Group("/v1/users")
+	followRepo := repository.NewFollowRepository(gorm)
+	followService := service.NewFollowService(followRepo, notificationService)
+	followHandler := handler.NewFollowHandler(followService, userService)
+	followRouter := router.NewFollowRouter(followRouteGroup, followHandler, auth)
+	followRouter.Mount()
+
+	reactionRepo := repository.NewReactionRepository(gorm)
+	reactionService := service.NewReactionService(reactionRepo, notificationService, eventHub)
+
 	photoRouteGroup := g.Group("/v1/photos")
 	photoRepo := repository.NewPhotoRepository(gorm)
-	photoService := service.NewPhotoService(photoRepo)
+	photoService := service.NewPhotoService(photoRepo, tagRepo, mentionService, storage, webhookService, txManager)
 	photoHandler := handler.NewPhotoHandler(photoService)
 	photoRouter := router.NewPhotoRouter(photoRouteGroup, photoHandler, auth)
 	photoRouter.Mount()
 
 	commentRouteGroup := g.Group("/v1/comments")
 	commentRepo := repository.NewCommentRepository(gorm)
-	commentService := service.NewCommentService(commentRepo)
+	commentService := service.NewCommentService(commentRepo, photoRepo, mentionService, notificationService, eventHub, webhookService, reactionService, txManager, cfg.Comments)
 	commentHandler := handler.NewCommentHandler(commentService, photoService)
 	commentRouter := router.NewCommentRouter(commentRouteGroup, commentHandler, auth)
 	commentRouter.Mount()
 
+	reactionRouteGroup := g.Group("/v1")
+	reactionHandler := handler.NewReactionHandler(reactionService, commentService, photoService)
+	reactionRouter := router.NewReactionRouter(reactionRouteGroup, reactionHandler, auth)
+	reactionRouter.Mount()
+
 	socialMediaRouteGroup := g.Group("/v1/socialmedias")
-	socialMediaRepo := repository.NewSocialMediaRepository(gorm)
-	socialMediaService := service.NewSocialMediaService(socialMediaRepo)
-	socialMediaHandler := handler.NewSocialMediaHandler(socialMediaService)
+	socialMediaHandler := handler.NewSocialMediaHandler(socialMediaService, userService)
 	socialMediaRouter := router.NewSocialMediaRouter(socialMediaRouteGroup, socialMediaHandler, auth)
 	socialMediaRouter.Mount()
 
-	g.GET("/ping", func(ctx *gin.Context) {
-		ctx.Writer.Write([]byte("Server online"))
-	})
+	albumRouteGroup := g.Group("/v1/albums")
+	albumRepo := repository.NewAlbumRepository(gorm)
+	albumService := service.NewAlbumService(albumRepo)
+	albumHandler := handler.NewAlbumHandler(albumService, photoService)
+	albumRouter := router.NewAlbumRouter(albumRouteGroup, albumHandler, auth)
+	albumRouter.Mount()
+
+	tagRouteGroup := g.Group("/v1/tags")
+	tagService := service.NewTagService(tagRepo)
+	tagHandler := handler.NewTagHandler(tagService)
+	tagRouter := router.NewTagRouter(tagRouteGroup, tagHandler, auth)
+	tagRouter.Mount()
+
+	if cfg.Features.Search {
+		searchRouteGroup := g.Group("/v1/search")
+		searchRepo := repository.NewSearchRepository(gorm)
+		searchService := service.NewSearchService(searchRepo)
+		searchHandler := handler.NewSearchHandler(searchService)
+		searchRouter := router.NewSearchRouter(searchRouteGroup, searchHandler, auth)
+		searchRouter.Mount()
+	}
+
+	trashRouteGroup := g.Group("/v1/trash")
+	trashRepo := repository.NewTrashRepository(gorm)
+	trashService := service.NewTrashService(trashRepo, storage, cfg.Trash)
+	backgroundWorkers.Go(trashService.RunPurgeWorker)
+	trashHandler := handler.NewTrashHandler(trashService)
+	trashRouter := router.NewTrashRouter(trashRouteGroup, trashHandler, auth)
+	trashRouter.Mount()
+
+	notificationRouteGroup := g.Group("/v1/notifications")
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	notificationRouter := router.NewNotificationRouter(notificationRouteGroup, notificationHandler, auth)
+	notificationRouter.Mount()
+
+	streamRouteGroup := g.Group("/v1/stream")
+	streamHandler := handler.NewStreamHandler(eventHub)
+	streamRouter := router.NewStreamRouter(streamRouteGroup, streamHandler, auth)
+	streamRouter.Mount()
+
+	webhookRouteGroup := g.Group("/v1/webhooks")
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	webhookRouter := router.NewWebhookRouter(webhookRouteGroup, webhookHandler, auth)
+	webhookRouter.Mount()
+
+	g.Static(cfg.Storage.BaseUrl, cfg.Storage.Dir)
+
+	if cfg.Features.Swagger {
+		g.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           g,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+	server.RegisterOnShutdown(eventHub.Close)
+
+	signalCtx, stopSignal := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignal()
 
-	g.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("cannot start server", "error", err)
+		}
+	}()
+
+	<-signalCtx.Done()
+	// a second signal kill the process without waiting
+	stopSignal()
+	slog.Info("shutting down", "drain_delay", cfg.Server.DrainDelay.String(), "timeout", cfg.Server.ShutdownTimeout.String())
+
+	// the instance is reported not ready while it still serve, so the load
+	// balancer stop sending requests before the listener is closed
+	healthService.ShutDown()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	err = server.Shutdown(shutdownCtx)
+	if err != nil {
+		slog.Error("cannot drain the requests", "error", err)
+	}
+
+	err = backgroundWorkers.Stop(shutdownCtx)
+	if err != nil {
+		slog.Error("cannot drain the workers", "error", err)
+	}
+
+	err = gorm.Close()
+	if err != nil {
+		slog.Error("cannot close the database", "error", err)
+	}
+}
 
-	g.Run("127.0.0.1:3000")
+// fatal log the error and exit, log.Fatal would log it at the info level.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
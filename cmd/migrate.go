@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/zikri124/mygram-api/internal/config"
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/migration"
+)
+
+const migrateUsage = "usage: migrate up | down | status | to <version> | baseline <version>"
+
+// runMigrate run the migrate subcommand with args, the arguments after
+// "migrate".
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		fatal(migrateUsage)
+	}
+
+	migrator, err := migration.NewMigrator(infrastructure.NewGormPostgres(cfg.Database))
+	if err != nil {
+		fatal("cannot load migrations", "error", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		migrations, err := migrator.Up(ctx)
+		if err != nil {
+			fatal("cannot migrate up", "error", err)
+		}
+		printMigrations("applied", migrations)
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			fatal("cannot migrate down", "error", err)
+		}
+		if reverted == nil {
+			fmt.Println("no migration to revert")
+			return
+		}
+		printMigrations("reverted", []migration.Migration{*reverted})
+	case "to":
+		if len(args) != 2 {
+			fatal(migrateUsage)
+		}
+
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			fatal("invalid version", "version", args[1])
+		}
+
+		migrations, err := migrator.To(ctx, version)
+		if err != nil {
+			fatal("cannot migrate to version", "version", version, "error", err)
+		}
+		printMigrations("migrated", migrations)
+	case "baseline":
+		// adopt a database created before the migrations, its schema must
+		// match the given version, there is no default as recording a
+		// migration which did not run leave its changes missing
+		if len(args) != 2 {
+			fatal(migrateUsage)
+		}
+
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			fatal("invalid version", "version", args[1])
+		}
+
+		migrations, err := migrator.Baseline(ctx, version)
+		if err != nil {
+			fatal("cannot baseline database", "version", version, "error", err)
+		}
+		printMigrations("baselined", migrations)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fatal("cannot get migrations status", "error", err)
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d %-30s %s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		fatal(migrateUsage)
+	}
+}
+
+func printMigrations(action string, migrations []migration.Migration) {
+	if len(migrations) == 0 {
+		fmt.Println("database is up to date")
+		return
+	}
+
+	for _, migration := range migrations {
+		fmt.Printf("%s %04d_%s\n", action, migration.Version, migration.Name)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// workers run the background loops, so they can be stopped together and
+// awaited on shutdown. Stopping happen in two steps: stop is closed so the
+// loops stop claiming new work, then ctx is cancelled to abort the jobs still
+// running once the shutdown deadline is reached.
+type workers struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	stopCtx    context.Context
+	stopCancel context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+func newWorkers() *workers {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopCtx, stopCancel := context.WithCancel(ctx)
+	return &workers{ctx: ctx, cancel: cancel, stopCtx: stopCtx, stopCancel: stopCancel}
+}
+
+// Go run a loop that must return once stop is closed, the work it has
+// already started keep ctx until the shutdown deadline.
+func (w *workers) Go(run func(ctx context.Context, stop <-chan struct{})) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		run(w.ctx, w.stopCtx.Done())
+	}()
+}
+
+// GoUntilStop run a loop without work to finish, its ctx is cancelled as
+// soon as Stop is called.
+func (w *workers) GoUntilStop(run func(ctx context.Context)) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		run(w.stopCtx)
+	}()
+}
+
+// Stop close the stop channel of the workers and wait for every worker to
+// return. When ctx is done first, the running jobs are cancelled and Stop
+// return the ctx error.
+func (w *workers) Stop(ctx context.Context) error {
+	w.stopCancel()
+	defer w.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkersStop(t *testing.T) {
+	t.Run("running job finish before the deadline", func(t *testing.T) {
+		w := newWorkers()
+		started := make(chan struct{})
+		finished := make(chan error, 1)
+
+		w.Go(func(ctx context.Context, stop <-chan struct{}) {
+			close(started)
+			<-stop
+			select {
+			case <-time.After(50 * time.Millisecond):
+				finished <- nil
+			case <-ctx.Done():
+				finished <- ctx.Err()
+			}
+		})
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		assert.NoError(t, w.Stop(ctx))
+		assert.NoError(t, <-finished)
+	})
+
+	t.Run("running job is cancelled at the deadline", func(t *testing.T) {
+		w := newWorkers()
+		cancelled := make(chan struct{})
+
+		w.Go(func(ctx context.Context, stop <-chan struct{}) {
+			<-ctx.Done()
+			close(cancelled)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, w.Stop(ctx), context.DeadlineExceeded)
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("job was not cancelled")
+		}
+	})
+
+	t.Run("loop without work return on stop", func(t *testing.T) {
+		w := newWorkers()
+		w.GoUntilStop(func(ctx context.Context) {
+			<-ctx.Done()
+		})
+
+		assert.NoError(t, w.Stop(context.Background()))
+	})
+}
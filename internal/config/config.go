@@ -0,0 +1,180 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/middleware"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/logging"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
+)
+
+type ServerConfig struct {
+	Addr              string        `yaml:"addr" env:"LISTEN_ADDR"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	// WriteTimeout does not apply to the event streams.
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	// DrainDelay is how long /readyz fail before the server is shut down, a
+	// few probe periods so the load balancer stop routing to the instance.
+	DrainDelay time.Duration `yaml:"drain_delay" env:"SERVER_DRAIN_DELAY"`
+	// ShutdownTimeout is how long the in-flight requests and the workers
+	// have to finish once a stop signal is received.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+}
+
+type IdConfig struct {
+	// Node must be unique for each instance sharing the database.
+	Node int `yaml:"node" env:"ID_NODE"`
+	// PublicSecret is the key of the public ids permutation, it is required
+	// as without it anyone can decode the public ids. Changing it change
+	// every public id.
+	PublicSecret string `yaml:"public_secret" env:"PUBLIC_ID_SECRET"`
+}
+
+// FeatureConfig turn off parts of the API, the routes of a disabled feature
+// are not mounted.
+type FeatureConfig struct {
+	Registration bool `yaml:"registration" env:"FEATURE_REGISTRATION"`
+	Search       bool `yaml:"search" env:"FEATURE_SEARCH"`
+	Exports      bool `yaml:"exports" env:"FEATURE_EXPORTS"`
+	Swagger      bool `yaml:"swagger" env:"FEATURE_SWAGGER"`
+}
+
+type Config struct {
+	Server      ServerConfig                 `yaml:"server"`
+	Database    infrastructure.DbConfig      `yaml:"database"`
+	Storage     infrastructure.StorageConfig `yaml:"storage"`
+	EventBroker string                       `yaml:"event_broker" env:"EVENT_BROKER"`
+	Jwt         service.JwtConfig            `yaml:"jwt"`
+	Cors        middleware.CorsConfig        `yaml:"cors"`
+	RateLimit   middleware.RateLimitConfig   `yaml:"rate_limit"`
+	Features    FeatureConfig                `yaml:"features"`
+	Ids         IdConfig                     `yaml:"ids"`
+	Comments    service.CommentConfig        `yaml:"comments"`
+	Trash       service.TrashConfig          `yaml:"trash"`
+	Health      service.HealthConfig         `yaml:"health"`
+	Log         logging.Config               `yaml:"log"`
+}
+
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:              "127.0.0.1:3000",
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       time.Minute,
+			WriteTimeout:      time.Minute,
+			IdleTimeout:       2 * time.Minute,
+			DrainDelay:        5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: infrastructure.DbConfig{
+			Port:    "5432",
+			SSLMODE: "prefer",
+		},
+		Storage: infrastructure.StorageConfig{
+			Dir:       "uploads",
+			BaseUrl:   "/uploads",
+			ExportDir: "exports",
+		},
+		EventBroker: infrastructure.EventBrokerMemory,
+		Jwt:         service.JwtConfig{TTL: 12 * time.Hour},
+		Cors:        middleware.CorsConfig{AllowOrigins: []string{"*"}},
+		RateLimit: middleware.RateLimitConfig{
+			Enabled: false,
+			Rate:    10,
+			Burst:   20,
+		},
+		Features: FeatureConfig{
+			Registration: true,
+			Search:       true,
+			Exports:      true,
+			Swagger:      true,
+		},
+		Comments: service.CommentConfig{MaxDepth: 3},
+		Trash:    service.TrashConfig{RetentionDays: 30},
+		Health:   service.HealthConfig{CheckTimeout: 2 * time.Second},
+		Log:      logging.Config{Level: "info"},
+	}
+}
+
+// Validate return every invalid setting at once.
+func (c *Config) Validate() error {
+	errs := []error{}
+
+	if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("server.addr: %w", err))
+	}
+
+	timeouts := []struct {
+		path  string
+		value time.Duration
+	}{
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"health.check_timeout", c.Health.CheckTimeout},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", timeout.path))
+		}
+	}
+
+	if c.Server.DrainDelay < 0 {
+		errs = append(errs, errors.New("server.drain_delay must not be negative"))
+	}
+
+	required := []struct{ path, value string }{
+		{"database.host", c.Database.Host},
+		{"database.user", c.Database.User},
+		{"database.name", c.Database.DBName},
+		{"database.port", c.Database.Port},
+		{"storage.dir", c.Storage.Dir},
+		{"storage.export_dir", c.Storage.ExportDir},
+		{"jwt.secret", c.Jwt.Secret},
+		{"ids.public_secret", c.Ids.PublicSecret},
+	}
+	for _, setting := range required {
+		if setting.value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", setting.path))
+		}
+	}
+
+	if c.EventBroker != infrastructure.EventBrokerMemory && c.EventBroker != infrastructure.EventBrokerPostgres {
+		errs = append(errs, fmt.Errorf("event_broker must be %s or %s", infrastructure.EventBrokerMemory, infrastructure.EventBrokerPostgres))
+	}
+
+	if c.Jwt.TTL <= 0 {
+		errs = append(errs, errors.New("jwt.ttl must be positive"))
+	}
+
+	if c.RateLimit.Enabled && (c.RateLimit.Rate <= 0 || c.RateLimit.Burst < 1) {
+		errs = append(errs, errors.New("rate_limit.rate must be positive and rate_limit.burst at least 1"))
+	}
+
+	if c.Ids.Node < 0 || c.Ids.Node > snowflake.MaxNode {
+		errs = append(errs, fmt.Errorf("ids.node: %w", snowflake.ErrInvalidNode))
+	}
+
+	if c.Comments.MaxDepth < 0 {
+		errs = append(errs, errors.New("comments.max_depth must not be negative"))
+	}
+
+	if _, err := c.Log.ParseLevel(); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+
+	if c.Trash.RetentionDays < 1 {
+		errs = append(errs, errors.New("trash.retention_days must be at least 1"))
+	}
+
+	return errors.Join(errs...)
+}
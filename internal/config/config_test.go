@@ -0,0 +1,88 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("layers override each other by precedence", func(t *testing.T) {
+		path := writeFile(t, `
+database:
+  host: file-host
+  user: file-user
+  name: mygram
+jwt:
+  secret: file-secret
+  ttl: 1h
+ids:
+  public_secret: file-public-secret
+cors:
+  allow_origins: [https://mygram.example]
+`)
+		t.Setenv("CONFIG_FILE", path)
+		t.Setenv("DB_USER", "env-user")
+		t.Setenv("JWT_SECRET", "env-secret")
+		t.Setenv("FEATURE_SEARCH", "false")
+
+		config, rest, err := Load([]string{"-jwt.secret", "flag-secret", "-rate_limit.enabled", "migrate", "up"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "file-host", config.Database.Host)
+		assert.Equal(t, "env-user", config.Database.User)
+		assert.Equal(t, "flag-secret", config.Jwt.Secret)
+		assert.Equal(t, time.Hour, config.Jwt.TTL)
+		assert.Equal(t, []string{"https://mygram.example"}, config.Cors.AllowOrigins)
+		assert.False(t, config.Features.Search)
+		assert.True(t, config.Features.Registration)
+		assert.True(t, config.RateLimit.Enabled)
+		assert.Equal(t, "127.0.0.1:3000", config.Server.Addr)
+		assert.Equal(t, []string{"migrate", "up"}, rest)
+	})
+
+	t.Run("list from env is comma separated", func(t *testing.T) {
+		t.Setenv("DB_HOST", "localhost")
+		t.Setenv("DB_USER", "mygram")
+		t.Setenv("DB_NAME", "mygram")
+		t.Setenv("JWT_SECRET", "secret")
+		t.Setenv("PUBLIC_ID_SECRET", "public-secret")
+		t.Setenv("CORS_ALLOW_ORIGINS", "https://a.example, https://b.example")
+
+		config, _, err := Load(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://a.example", "https://b.example"}, config.Cors.AllowOrigins)
+	})
+
+	t.Run("unknown key in file is an error", func(t *testing.T) {
+		_, _, err := Load([]string{"-config", writeFile(t, "databse:\n  host: localhost\n")})
+		assert.ErrorContains(t, err, "databse")
+	})
+
+	t.Run("invalid env value is an error", func(t *testing.T) {
+		t.Setenv("ID_NODE", "first")
+
+		_, _, err := Load(nil)
+		assert.ErrorContains(t, err, "ID_NODE")
+	})
+
+	t.Run("missing settings fail validation", func(t *testing.T) {
+		_, _, err := Load([]string{"-ids.node", "16", "-event_broker", "redis", "-server.drain_delay", "-1s"})
+		assert.ErrorContains(t, err, "jwt.secret is required")
+		assert.ErrorContains(t, err, "ids.public_secret is required")
+		assert.ErrorContains(t, err, "database.host is required")
+		assert.ErrorContains(t, err, "ids.node")
+		assert.ErrorContains(t, err, "event_broker")
+		assert.ErrorContains(t, err, "server.drain_delay")
+	})
+}
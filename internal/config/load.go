@@ -0,0 +1,210 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setting is a leaf field of Config, path is its dotted yaml path which is
+// also the name of its flag.
+type setting struct {
+	path  string
+	env   string
+	value reflect.Value
+}
+
+// Load build the config from, by increasing precedence, the defaults, the
+// yaml file given by the -config flag or CONFIG_FILE env, the env vars
+// (.env is read when it exists) and the flags, e.g. -database.host. It
+// return the arguments after the flags, the subcommand.
+func Load(args []string) (*Config, []string, error) {
+	config := Default()
+	settings := collect(reflect.ValueOf(&config).Elem(), "")
+
+	flags := flag.NewFlagSet("mygram", flag.ContinueOnError)
+	configFile := flags.String("config", "", "yaml config file, env CONFIG_FILE")
+
+	flagValues := map[string]reflect.Value{}
+	for _, s := range settings {
+		flags.Var(&flagValue{setting: s, values: flagValues}, s.path, s.usage())
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = godotenv.Load()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("cannot load .env: %w", err)
+	}
+
+	if *configFile == "" {
+		*configFile = os.Getenv("CONFIG_FILE")
+	}
+
+	if *configFile != "" {
+		err = readFile(*configFile, &config)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, s := range settings {
+		raw, ok := os.LookupEnv(s.env)
+		if s.env == "" || !ok || raw == "" {
+			continue
+		}
+
+		value, err := parse(s.value.Type(), raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("env %s: %w", s.env, err)
+		}
+		s.value.Set(value)
+	}
+
+	for _, s := range settings {
+		if value, ok := flagValues[s.path]; ok {
+			s.value.Set(value)
+		}
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return &config, flags.Args(), nil
+}
+
+// readFile decode the yaml file on config, an unknown key is an error so a
+// typo is not silently ignored.
+func readFile(path string, config *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(config)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("cannot decode config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func collect(value reflect.Value, prefix string) []setting {
+	settings := []setting{}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		path := prefix + field.Tag.Get("yaml")
+
+		if field.Type.Kind() == reflect.Struct {
+			settings = append(settings, collect(value.Field(i), path+".")...)
+			continue
+		}
+
+		settings = append(settings, setting{path: path, env: field.Tag.Get("env"), value: value.Field(i)})
+	}
+
+	return settings
+}
+
+func (s setting) usage() string {
+	if s.env == "" {
+		return ""
+	}
+	return "env " + s.env
+}
+
+// parse a flag or env value of typ, lists are comma separated.
+func parse(typ reflect.Type, raw string) (reflect.Value, error) {
+	value := reflect.New(typ).Elem()
+
+	switch {
+	case typ == durationType:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return value, err
+		}
+		value.SetInt(int64(duration))
+	case typ.Kind() == reflect.String:
+		value.SetString(raw)
+	case typ.Kind() == reflect.Int:
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return value, err
+		}
+		value.SetInt(int64(number))
+	case typ.Kind() == reflect.Float64:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return value, err
+		}
+		value.SetFloat(number)
+	case typ.Kind() == reflect.Bool:
+		boolean, err := strconv.ParseBool(raw)
+		if err != nil {
+			return value, err
+		}
+		value.SetBool(boolean)
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.String:
+		items := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return value, fmt.Errorf("unsupported config type %s", typ)
+	}
+
+	return value, nil
+}
+
+// flagValue keep the parsed flag in values, flags are applied last to
+// override the file and the env.
+type flagValue struct {
+	setting setting
+	values  map[string]reflect.Value
+}
+
+func (f *flagValue) String() string {
+	if f.values == nil {
+		return ""
+	}
+	return fmt.Sprint(f.setting.value.Interface())
+}
+
+func (f *flagValue) Set(raw string) error {
+	value, err := parse(f.setting.value.Type(), raw)
+	if err != nil {
+		return err
+	}
+
+	f.values[f.setting.path] = value
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.values != nil && f.setting.value.Kind() == reflect.Bool
+}
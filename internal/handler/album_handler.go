@@ -0,0 +1,551 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+type AlbumHandler interface {
+	PostAlbum(ctx *gin.Context)
+	GetAllAlbumsByUserId(ctx *gin.Context)
+	GetAlbumById(ctx *gin.Context)
+	UpdateAlbum(ctx *gin.Context)
+	DeleteAlbum(ctx *gin.Context)
+	AddPhotoToAlbum(ctx *gin.Context)
+	RemovePhotoFromAlbum(ctx *gin.Context)
+	ReorderAlbumPhotos(ctx *gin.Context)
+}
+
+type albumHandlerImpl struct {
+	svc      service.AlbumService
+	photoSvc service.PhotoService
+}
+
+func NewAlbumHandler(svc service.AlbumService, photoSvc service.PhotoService) AlbumHandler {
+	return &albumHandlerImpl{svc: svc, photoSvc: photoSvc}
+}
+
+// Create Album godoc
+//
+// @Summary		Create album
+// @Description	Create an album for the login user
+// @Tags		album
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		album	body		model.CreateAlbum	true	"New Album"
+// @Success		201		{object}	model.AlbumRes
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/albums [post]
+func (a *albumHandlerImpl) PostAlbum(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	newAlbum := model.CreateAlbum{}
+	err = ctx.ShouldBindJSON(&newAlbum)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(newAlbum)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if newAlbum.CoverPhotoId != nil {
+		photo, err := a.photoSvc.GetPhotoById(ctx, *newAlbum.CoverPhotoId)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+			return
+		}
+
+		if photo.ID == 0 {
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Cover photo did not exist"))
+			return
+		}
+
+		if userId != photo.UserId {
+			ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+			return
+		}
+	}
+
+	albumRes, err := a.svc.PostAlbum(ctx, userId, newAlbum)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, albumRes)
+}
+
+// Get Album godoc
+//
+// @Summary		Get all albums of a user by user id
+// @Description	Return a page of album data with the photo count of each album, newest first by default
+// @Tags		album
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param       user_id    query    string  false  "user id of the owner"
+// @Param		limit	query		int		false	"Albums per page, default 20, maximum 100"
+// @Param		sort	query		string	false	"newest or oldest"
+// @Param		cursor	query		string	false	"next_cursor of the previous page"
+// @Success		200		{object}	pagination.Page{data=[]model.AlbumView}
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/albums [get]
+func (a *albumHandlerImpl) GetAllAlbumsByUserId(ctx *gin.Context) {
+	userIdStr := ctx.Request.URL.Query().Get("user_id")
+	if userIdStr == "" {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Missing User id in query"))
+		return
+	}
+	userId, err := helper.ParseId(userIdStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	params, err := pagination.FromQuery(ctx.Request.URL.Query(), pagination.SortNewest, model.AlbumSorts...)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	albums, err := a.svc.GetAllAlbumsByUserId(ctx, userId, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, albums)
+}
+
+// Get Album godoc
+//
+// @Summary		Get data of an album by album id
+// @Description	Get album data with its photos in order
+// @Tags		album
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Album ID"
+// @Success		200		{object}	model.AlbumDetail
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/albums/{id} [get]
+func (a *albumHandlerImpl) GetAlbumById(ctx *gin.Context) {
+	albumId, err := helper.ParseId(ctx.Param("id"))
+	if albumId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid album id"))
+		return
+	}
+
+	album, err := a.svc.GetAlbumDetail(ctx, albumId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if album.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Album did not exist"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, album)
+}
+
+// Edit Album godoc
+//
+// @Summary		Edit album data by album id
+// @Description	Edit title, description and cover photo of an album
+// @Tags		album
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Album id"
+// @Param		album	body		model.UpdateAlbum	true	"New Album Editted"
+// @Success		200		{object}	model.AlbumRes
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/albums/{id} [put]
+func (a *albumHandlerImpl) UpdateAlbum(ctx *gin.Context) {
+	albumId, err := helper.ParseId(ctx.Param("id"))
+	if albumId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid album id"))
+		return
+	}
+
+	album, err := a.svc.GetAlbumById(ctx, albumId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if album.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Album did not exist"))
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if userId != album.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+		return
+	}
+
+	albumEditData := model.UpdateAlbum{}
+	err = ctx.ShouldBindJSON(&albumEditData)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(albumEditData)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if albumEditData.CoverPhotoId != nil {
+		photo, err := a.photoSvc.GetPhotoById(ctx, *albumEditData.CoverPhotoId)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+			return
+		}
+
+		if photo.ID == 0 {
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Cover photo did not exist"))
+			return
+		}
+
+		if userId != photo.UserId {
+			ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+			return
+		}
+	}
+
+	albumUpdate := model.Album{ID: album.ID, UserId: album.UserId, CreatedAt: album.CreatedAt}
+	albumUpdate.Title = albumEditData.Title
+	albumUpdate.Description = albumEditData.Description
+	albumUpdate.CoverPhotoId = albumEditData.CoverPhotoId
+
+	albumRes, err := a.svc.UpdateAlbum(ctx, albumUpdate)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, albumRes)
+}
+
+// Delete Album godoc
+//
+// @Summary		Delete an album
+// @Description	Delete by id, the photos inside the album are not deleted
+// @Tags		album
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Album Id"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/albums/{id} [delete]
+func (a *albumHandlerImpl) DeleteAlbum(ctx *gin.Context) {
+	albumId, err := helper.ParseId(ctx.Param("id"))
+	if albumId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid album id"))
+		return
+	}
+
+	album, err := a.svc.GetAlbumById(ctx, albumId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if album.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Album did not exist"))
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if userId != album.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+		return
+	}
+
+	err = a.svc.DeleteAlbum(ctx, albumId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "Your album has been successfully deleted"})
+}
+
+// Add Album Photo godoc
+//
+// @Summary		Add a photo to an album
+// @Description	The photo is placed at the end of the album, only the owner photos can be added
+// @Tags		album
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Album id"
+// @Param		photo	body		model.AddAlbumPhoto	true	"Photo to add"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		409		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/albums/{id}/photos [post]
+func (a *albumHandlerImpl) AddPhotoToAlbum(ctx *gin.Context) {
+	albumId, err := helper.ParseId(ctx.Param("id"))
+	if albumId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid album id"))
+		return
+	}
+
+	album, err := a.svc.GetAlbumById(ctx, albumId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if album.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Album did not exist"))
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if userId != album.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+		return
+	}
+
+	albumPhotoData := model.AddAlbumPhoto{}
+	err = ctx.ShouldBindJSON(&albumPhotoData)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(albumPhotoData)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	photo, err := a.photoSvc.GetPhotoById(ctx, albumPhotoData.PhotoId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if photo.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Photo did not exist"))
+		return
+	}
+
+	if userId != photo.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+		return
+	}
+
+	// the primary key of album_photos refuse a duplicate, also when the same
+	// photo is added by two requests at once
+	err = a.svc.AddPhotoToAlbum(ctx, album.ID, photo.ID)
+	if errors.Is(err, service.ErrPhotoAlreadyInAlbum) {
+		ctx.JSON(http.StatusConflict, response.NewErrorResponse(ctx, "Photo already in the album"))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "Photo has been successfully added to the album"})
+}
+
+// Remove Album Photo godoc
+//
+// @Summary		Remove a photo from an album
+// @Description	The photo itself is not deleted
+// @Tags		album
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id			path		string	true	"Album id"
+// @Param		photo_id	path		string	true	"Photo id"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/albums/{id}/photos/{photo_id} [delete]
+func (a *albumHandlerImpl) RemovePhotoFromAlbum(ctx *gin.Context) {
+	albumId, err := helper.ParseId(ctx.Param("id"))
+	if albumId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid album id"))
+		return
+	}
+
+	photoId, err := helper.ParseId(ctx.Param("photo_id"))
+	if photoId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid photo id"))
+		return
+	}
+
+	album, err := a.svc.GetAlbumById(ctx, albumId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if album.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Album did not exist"))
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if userId != album.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+		return
+	}
+
+	err = a.svc.RemovePhotoFromAlbum(ctx, album.ID, photoId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "Photo has been successfully removed from the album"})
+}
+
+// Reorder Album Photos godoc
+//
+// @Summary		Reorder the photos of an album
+// @Description	photo_ids must contain every photo of the album exactly once, in the new order
+// @Tags		album
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Album id"
+// @Param		order	body		model.ReorderAlbumPhotos	true	"New photo order"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/albums/{id}/photos [put]
+func (a *albumHandlerImpl) ReorderAlbumPhotos(ctx *gin.Context) {
+	albumId, err := helper.ParseId(ctx.Param("id"))
+	if albumId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid album id"))
+		return
+	}
+
+	album, err := a.svc.GetAlbumById(ctx, albumId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if album.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Album did not exist"))
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if userId != album.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+		return
+	}
+
+	orderData := model.ReorderAlbumPhotos{}
+	err = ctx.ShouldBindJSON(&orderData)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(orderData)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	albumPhotos, err := a.svc.GetAlbumPhotos(ctx, album.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	inAlbum := map[publicid.ID]bool{}
+	for _, albumPhoto := range albumPhotos {
+		inAlbum[albumPhoto.PhotoId] = true
+	}
+
+	if len(orderData.PhotoIds) != len(inAlbum) {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "photo_ids must contain every photo of the album exactly once"))
+		return
+	}
+
+	for _, photoId := range orderData.PhotoIds {
+		if !inAlbum[photoId] {
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "photo_ids must contain every photo of the album exactly once"))
+			return
+		}
+		delete(inAlbum, photoId)
+	}
+
+	err = a.svc.ReorderAlbumPhotos(ctx, album.ID, orderData.PhotoIds)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "Album photos has been successfully reordered"})
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/internal/service/mocks"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+func newAlbumRequest(method string, albumId publicid.ID, body string, userId publicid.ID) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+
+	req := httptest.NewRequest(method, "/v1/albums/"+albumId.String()+"/photos", bytes.NewBuffer([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	g, _ := gin.CreateTestContext(rec)
+	g.Request = req
+	g.Params = gin.Params{{Key: "id", Value: albumId.String()}}
+	g.Set("UserId", float64(userId))
+
+	return g, rec
+}
+
+func TestPostAlbum(t *testing.T) {
+	t.Run("create album", func(t *testing.T) {
+		g, rec := newAlbumRequest(http.MethodPost, 0, `{"title":"Holidays"}`, 1)
+
+		serviceMock := mocks.NewAlbumService(t)
+		serviceMock.
+			On("PostAlbum", g, publicid.ID(1), model.CreateAlbum{Title: "Holidays"}).
+			Return(&model.AlbumRes{ID: 10, UserId: 1, Title: "Holidays"}, nil)
+
+		albumHandler := albumHandlerImpl{svc: serviceMock}
+		albumHandler.PostAlbum(g)
+
+		assert.Equal(t, http.StatusCreated, rec.Result().StatusCode)
+	})
+}
+
+func TestAddPhotoToAlbum(t *testing.T) {
+	albumId := publicid.ID(10)
+	photoId := publicid.ID(20)
+	body := `{"photo_id":"` + photoId.String() + `"}`
+
+	t.Run("album of another user", func(t *testing.T) {
+		g, rec := newAlbumRequest(http.MethodPost, albumId, body, 1)
+
+		serviceMock := mocks.NewAlbumService(t)
+		serviceMock.On("GetAlbumById", g, albumId).Return(&model.AlbumView{ID: albumId, UserId: 2}, nil)
+
+		albumHandler := albumHandlerImpl{svc: serviceMock}
+		albumHandler.AddPhotoToAlbum(g)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Result().StatusCode)
+	})
+
+	t.Run("photo of another user", func(t *testing.T) {
+		g, rec := newAlbumRequest(http.MethodPost, albumId, body, 1)
+
+		serviceMock := mocks.NewAlbumService(t)
+		serviceMock.On("GetAlbumById", g, albumId).Return(&model.AlbumView{ID: albumId, UserId: 1}, nil)
+		photoServiceMock := mocks.NewPhotoService(t)
+		photoServiceMock.On("GetPhotoById", g, photoId).Return(&model.PhotoView{ID: photoId, UserId: 2}, nil)
+
+		albumHandler := albumHandlerImpl{svc: serviceMock, photoSvc: photoServiceMock}
+		albumHandler.AddPhotoToAlbum(g)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Result().StatusCode)
+	})
+
+	t.Run("photo already in the album", func(t *testing.T) {
+		g, rec := newAlbumRequest(http.MethodPost, albumId, body, 1)
+
+		serviceMock := mocks.NewAlbumService(t)
+		serviceMock.On("GetAlbumById", g, albumId).Return(&model.AlbumView{ID: albumId, UserId: 1}, nil)
+		serviceMock.On("AddPhotoToAlbum", g, albumId, photoId).Return(service.ErrPhotoAlreadyInAlbum)
+		photoServiceMock := mocks.NewPhotoService(t)
+		photoServiceMock.On("GetPhotoById", g, photoId).Return(&model.PhotoView{ID: photoId, UserId: 1}, nil)
+
+		albumHandler := albumHandlerImpl{svc: serviceMock, photoSvc: photoServiceMock}
+		albumHandler.AddPhotoToAlbum(g)
+
+		assert.Equal(t, http.StatusConflict, rec.Result().StatusCode)
+	})
+
+	t.Run("add photo", func(t *testing.T) {
+		g, rec := newAlbumRequest(http.MethodPost, albumId, body, 1)
+
+		serviceMock := mocks.NewAlbumService(t)
+		serviceMock.On("GetAlbumById", g, albumId).Return(&model.AlbumView{ID: albumId, UserId: 1}, nil)
+		serviceMock.On("AddPhotoToAlbum", g, albumId, photoId).Return(nil)
+		photoServiceMock := mocks.NewPhotoService(t)
+		photoServiceMock.On("GetPhotoById", g, photoId).Return(&model.PhotoView{ID: photoId, UserId: 1}, nil)
+
+		albumHandler := albumHandlerImpl{svc: serviceMock, photoSvc: photoServiceMock}
+		albumHandler.AddPhotoToAlbum(g)
+
+		assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
+
+func TestReorderAlbumPhotos(t *testing.T) {
+	albumId := publicid.ID(10)
+	first := publicid.ID(20)
+	second := publicid.ID(21)
+	albumPhotos := []model.AlbumPhotoView{{AlbumId: albumId, PhotoId: first, Position: 1}, {AlbumId: albumId, PhotoId: second, Position: 2}}
+
+	t.Run("album of another user", func(t *testing.T) {
+		g, rec := newAlbumRequest(http.MethodPut, albumId, `{"photo_ids":["`+second.String()+`","`+first.String()+`"]}`, 1)
+
+		serviceMock := mocks.NewAlbumService(t)
+		serviceMock.On("GetAlbumById", g, albumId).Return(&model.AlbumView{ID: albumId, UserId: 2}, nil)
+
+		albumHandler := albumHandlerImpl{svc: serviceMock}
+		albumHandler.ReorderAlbumPhotos(g)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Result().StatusCode)
+	})
+
+	t.Run("order missing a photo of the album", func(t *testing.T) {
+		g, rec := newAlbumRequest(http.MethodPut, albumId, `{"photo_ids":["`+second.String()+`","`+second.String()+`"]}`, 1)
+
+		serviceMock := mocks.NewAlbumService(t)
+		serviceMock.On("GetAlbumById", g, albumId).Return(&model.AlbumView{ID: albumId, UserId: 1}, nil)
+		serviceMock.On("GetAlbumPhotos", g, albumId).Return(albumPhotos, nil)
+
+		albumHandler := albumHandlerImpl{svc: serviceMock}
+		albumHandler.ReorderAlbumPhotos(g)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Result().StatusCode)
+	})
+
+	t.Run("reorder photos", func(t *testing.T) {
+		g, rec := newAlbumRequest(http.MethodPut, albumId, `{"photo_ids":["`+second.String()+`","`+first.String()+`"]}`, 1)
+
+		serviceMock := mocks.NewAlbumService(t)
+		serviceMock.On("GetAlbumById", g, albumId).Return(&model.AlbumView{ID: albumId, UserId: 1}, nil)
+		serviceMock.On("GetAlbumPhotos", g, albumId).Return(albumPhotos, nil)
+		serviceMock.On("ReorderAlbumPhotos", g, albumId, []publicid.ID{second, first}).Return(nil)
+
+		albumHandler := albumHandlerImpl{svc: serviceMock}
+		albumHandler.ReorderAlbumPhotos(g)
+
+		assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
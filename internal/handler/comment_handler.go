@@ -1,20 +1,22 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator"
 	"github.com/zikri124/mygram-api/internal/model"
 	"github.com/zikri124/mygram-api/internal/service"
 	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/pagination"
 	"github.com/zikri124/mygram-api/pkg/response"
 )
 
 type CommentHandler interface {
 	PostComment(ctx *gin.Context)
 	GetAllComments(ctx *gin.Context)
+	GetReplies(ctx *gin.Context)
 	GetCommentById(ctx *gin.Context)
 	UpdateComment(ctx *gin.Context)
 	DeleteComment(ctx *gin.Context)
@@ -32,7 +34,7 @@ func NewCommentHandler(svc service.CommentService, photoSvc service.PhotoService
 // Create Comment godoc
 //
 // @Summary		Create Comment
-// @Description	Create data to the login user
+// @Description	Create data to the login user, set parent_id to reply to a comment of the same photo
 // @Tags		comment
 // @Accept		json
 // @Produce		json
@@ -40,43 +42,66 @@ func NewCommentHandler(svc service.CommentService, photoSvc service.PhotoService
 // @Param		comment	body		model.CreateComment	true	"New Comment"
 // @Success		200		{object}	model.CreateCommentRes
 // @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/comments [post]
 func (c *commentHandlerImpl) PostComment(ctx *gin.Context) {
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	newComment := model.CreateComment{}
 	err = ctx.ShouldBindJSON(&newComment)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	validate := validator.New()
 	err = validate.Struct(newComment)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	photo, err := c.photoSvc.GetPhotoById(ctx, newComment.PhotoId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if photo.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Photo did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Photo did not exist"))
 		return
 	}
 
+	if newComment.ParentId != nil {
+		parent, err := c.svc.GetCommentById(ctx, *newComment.ParentId)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+			return
+		}
+
+		if parent.ID == 0 {
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Parent comment did not exist"))
+			return
+		}
+
+		if parent.PhotoId != newComment.PhotoId {
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "parent comment is not on this photo"))
+			return
+		}
+	}
+
 	commentRes, err := c.svc.PostComment(ctx, userId, newComment)
+	if errors.Is(err, service.ErrCommentMaxDepth) {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -86,37 +111,96 @@ func (c *commentHandlerImpl) PostComment(ctx *gin.Context) {
 // Get Comment godoc
 //
 // @Summary		Get all data of a photo by user id
-// @Description	Return an array of comments data
+// @Description	Return an array of the top level comments data with their reply count and reactions, deleted comments having replies are shown as "[deleted]"
 // @Tags		comment
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header 	string	true "Bearer token"
 // @Param       photo_id    query    string  false  "photo id of the comments owner"
-// @Success		200		{object}	[]model.CommentView
+// @Param		limit	query		int		false	"Comments per page, default 20, maximum 100"
+// @Param		sort	query		string	false	"oldest, newest, most_liked or most_commented"
+// @Param		cursor	query		string	false	"next_cursor of the previous page"
+// @Success		200		{object}	pagination.Page{data=[]model.CommentView}
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/comments [get]
 func (c *commentHandlerImpl) GetAllComments(ctx *gin.Context) {
 	photoIdStr := ctx.Request.URL.Query().Get("photo_id")
 	if photoIdStr == "" {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Missing Photo id in query"})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Missing Photo id in query"))
+		return
+	}
+	photoId, err := helper.ParseId(photoIdStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
-	photoId, err := strconv.Atoi(photoIdStr)
+
+	params, err := pagination.FromQuery(ctx.Request.URL.Query(), pagination.SortOldest, model.CommentSorts...)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	comments, err := c.svc.GetAllCommentsByPhotoId(ctx, uint32(photoId))
+	comments, err := c.svc.GetAllCommentsByPhotoId(ctx, photoId, params, userId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, comments)
 }
 
+// Get Comment Replies godoc
+//
+// @Summary		Get replies of a comment
+// @Description	Return a page of the direct replies of a comment, oldest first by default, with their own reply count
+// @Tags		comment
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Comment ID"
+// @Param		limit	query		int		false	"Replies per page, default 20, maximum 100"
+// @Param		sort	query		string	false	"oldest, newest, most_liked or most_commented"
+// @Param		cursor	query		string	false	"next_cursor of the previous page"
+// @Success		200		{object}	model.CommentRepliesRes{data=[]model.CommentView}
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/comments/{id}/replies [get]
+func (c *commentHandlerImpl) GetReplies(ctx *gin.Context) {
+	commentId, err := helper.ParseId(ctx.Param("id"))
+	if commentId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid comment id"))
+		return
+	}
+
+	params, err := pagination.FromQuery(ctx.Request.URL.Query(), pagination.SortOldest, model.CommentSorts...)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	replies, err := c.svc.GetRepliesByCommentId(ctx, commentId, params, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, replies)
+}
+
 // Get Comment godoc
 //
 // @Summary		Get data of a comment by photo id
@@ -125,26 +209,26 @@ func (c *commentHandlerImpl) GetAllComments(ctx *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header string	true "Bearer token"
-// @Param		id		path		int	true	"Comment ID"
+// @Param		id		path		string	true	"Comment ID"
 // @Success		200		{object}	model.CommentView
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/comments/{id} [get]
 func (c *commentHandlerImpl) GetCommentById(ctx *gin.Context) {
-	commentId, err := strconv.Atoi(ctx.Param("id"))
+	commentId, err := helper.ParseId(ctx.Param("id"))
 	if commentId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	comment, err := c.svc.GetCommentById(ctx, uint32(commentId))
+	comment, err := c.svc.GetCommentById(ctx, commentId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if comment.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Comment did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Comment did not exist"))
 		return
 	}
 
@@ -159,52 +243,52 @@ func (c *commentHandlerImpl) GetCommentById(ctx *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header string	true "Bearer token"
-// @Param		id		path		int	true	"Comment id"
+// @Param		id		path		string	true	"Comment id"
 // @Param		comment	body		model.UpdateComment	true	"New Comment Editted"
 // @Success		200		{object}	model.UpdateCommentRes
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/comments/{id} [put]
 func (c *commentHandlerImpl) UpdateComment(ctx *gin.Context) {
-	commentId, err := strconv.Atoi(ctx.Param("id"))
+	commentId, err := helper.ParseId(ctx.Param("id"))
 	if commentId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	commentData, err := c.svc.GetCommentById(ctx, uint32(commentId))
+	commentData, err := c.svc.GetCommentById(ctx, commentId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if commentData.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Comment did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Comment did not exist"))
 		return
 	}
 
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	if userId != uint32(commentData.UserId) {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized to do this request"})
+	if userId != commentData.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
 		return
 	}
 
 	commentEditData := model.UpdateComment{}
 	err = ctx.ShouldBindJSON(&commentEditData)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	validate := validator.New()
 	err = validate.Struct(commentEditData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -213,7 +297,7 @@ func (c *commentHandlerImpl) UpdateComment(ctx *gin.Context) {
 
 	commentRes, err := c.svc.UpdateComment(ctx, comment)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -223,48 +307,48 @@ func (c *commentHandlerImpl) UpdateComment(ctx *gin.Context) {
 // Delete Comment godoc
 //
 // @Summary		Delete any comment
-// @Description	Delete by id
+// @Description	Delete by id, a comment having replies stays in the thread as "[deleted]"
 // @Tags		comment
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header string	true "Bearer token"
-// @Param		id		path		int	true	"Comment Id"
+// @Param		id		path		string	true	"Comment Id"
 // @Success		200		{object}	response.SuccessResponse
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/comments/{id} [delete]
 func (c *commentHandlerImpl) DeleteComment(ctx *gin.Context) {
-	commentId, err := strconv.Atoi(ctx.Param("id"))
+	commentId, err := helper.ParseId(ctx.Param("id"))
 	if commentId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	photo, err := c.svc.GetCommentById(ctx, uint32(commentId))
+	photo, err := c.svc.GetCommentById(ctx, commentId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if photo.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "comment did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "comment did not exist"))
 		return
 	}
 
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	if userId != uint32(photo.UserId) {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized to do this request"})
+	if userId != photo.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
 		return
 	}
 
-	err = c.svc.DeleteComment(ctx, uint32(commentId))
+	err = c.svc.DeleteComment(ctx, commentId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
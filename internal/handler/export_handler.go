@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+type ExportHandler interface {
+	RequestExport(ctx *gin.Context)
+	GetExport(ctx *gin.Context)
+	DownloadExport(ctx *gin.Context)
+}
+
+type exportHandlerImpl struct {
+	svc service.ExportService
+}
+
+func NewExportHandler(svc service.ExportService) ExportHandler {
+	return &exportHandlerImpl{svc: svc}
+}
+
+// Request Export godoc
+//
+// @Summary		Request an export of the personal data of the login user
+// @Description	The ZIP archive with the profile, photos, comments, social medias, follows and likes is built in the background, poll the export until its status is ready to get the download url
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Success		202		{object}	model.DataExportRes
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/users/me/export [post]
+func (e *exportHandlerImpl) RequestExport(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	export, err := e.svc.RequestExport(ctx, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, export)
+}
+
+// Get Export godoc
+//
+// @Summary		Show the status of an export of the login user
+// @Description	The download url is set once the status is ready, it expires after 7 days
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Export id"
+// @Success		200		{object}	model.DataExportRes
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		401		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/users/me/export/{id} [get]
+func (e *exportHandlerImpl) GetExport(ctx *gin.Context) {
+	exportId, err := helper.ParseId(ctx.Param("id"))
+	if exportId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Invalid export id"))
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	export, err := e.svc.GetExportById(ctx, exportId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if export.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Export did not exist"))
+		return
+	}
+
+	if export.UserId != userId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, service.ToDataExportRes(*export))
+}
+
+// Download Export godoc
+//
+// @Summary		Download the ZIP archive of an export
+// @Description	The token of the download url is the authorization, the link stops working when it expires
+// @Tags		users
+// @Produce		application/zip
+// @Param		id		path		string		true	"Export id"
+// @Param		token	query		string	true	"Download token"
+// @Success		200		{file}		binary
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		410		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/exports/{id}/download [get]
+func (e *exportHandlerImpl) DownloadExport(ctx *gin.Context) {
+	exportId, err := helper.ParseId(ctx.Param("id"))
+	if exportId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Invalid export id"))
+		return
+	}
+
+	filePath, err := e.svc.GetExportFile(ctx, exportId, ctx.Query("token"))
+	if errors.Is(err, service.ErrExportNotFound) {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	if errors.Is(err, service.ErrExportExpired) {
+		ctx.JSON(http.StatusGone, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.FileAttachment(filePath, "mygram-export.zip")
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+type FollowHandler interface {
+	FollowUser(ctx *gin.Context)
+	UnfollowUser(ctx *gin.Context)
+}
+
+type followHandlerImpl struct {
+	svc     service.FollowService
+	userSvc service.UserService
+}
+
+func NewFollowHandler(svc service.FollowService, userSvc service.UserService) FollowHandler {
+	return &followHandlerImpl{svc: svc, userSvc: userSvc}
+}
+
+// Follow User godoc
+//
+// @Summary		Follow an user
+// @Description	Following an user already followed is not an error, the user is only notified the first time
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"User id"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/users/{id}/follow [post]
+func (f *followHandlerImpl) FollowUser(ctx *gin.Context) {
+	followerId, followeeId, ok := f.getFollowUsers(ctx)
+	if !ok {
+		return
+	}
+
+	err := f.svc.Follow(ctx, followerId, followeeId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "you are now following this user"})
+}
+
+// Unfollow User godoc
+//
+// @Summary		Unfollow an user
+// @Description	Unfollowing an user not followed is not an error
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"User id"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/users/{id}/follow [delete]
+func (f *followHandlerImpl) UnfollowUser(ctx *gin.Context) {
+	followerId, followeeId, ok := f.getFollowUsers(ctx)
+	if !ok {
+		return
+	}
+
+	err := f.svc.Unfollow(ctx, followerId, followeeId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "you are no longer following this user"})
+}
+
+// getFollowUsers return the login user and the existing user of the path,
+// the response is already written when ok is false.
+func (f *followHandlerImpl) getFollowUsers(ctx *gin.Context) (followerId publicid.ID, followeeId publicid.ID, ok bool) {
+	userId, err := helper.ParseId(ctx.Param("id"))
+	if userId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Invalid user id"))
+		return
+	}
+
+	followerId, err = helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if userId == followerId {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "cannot follow yourself"))
+		return
+	}
+
+	user, err := f.userSvc.GetUserById(ctx, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if user.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "User did not exist"))
+		return
+	}
+
+	return followerId, user.ID, true
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
+)
+
+type HealthHandler interface {
+	Healthz(ctx *gin.Context)
+	Readyz(ctx *gin.Context)
+}
+
+type healthHandlerImpl struct {
+	svc service.HealthService
+}
+
+func NewHealthHandler(svc service.HealthService) HealthHandler {
+	return &healthHandlerImpl{svc: svc}
+}
+
+// Healthz godoc
+//
+// @Summary		Liveness probe
+// @Description	Return 200 while the process is running, the dependencies are not checked
+// @Tags		health
+// @Produce		json
+// @Success		200		{object}	model.HealthRes
+// @Router		/healthz [get]
+func (h *healthHandlerImpl) Healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, model.HealthRes{Status: model.HealthStatusUp})
+}
+
+// Readyz godoc
+//
+// @Summary		Readiness probe
+// @Description	Check every dependency, return 503 with the failed checks when one is down or the server is shutting down
+// @Tags		health
+// @Produce		json
+// @Success		200		{object}	model.HealthRes
+// @Failure		503		{object}	model.HealthRes
+// @Router		/readyz [get]
+func (h *healthHandlerImpl) Readyz(ctx *gin.Context) {
+	ready, checks := h.svc.Ready(ctx)
+	if !ready {
+		ctx.JSON(http.StatusServiceUnavailable, model.HealthRes{Status: model.HealthStatusDown, Checks: checks})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.HealthRes{Status: model.HealthStatusUp, Checks: checks})
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+type NotificationHandler interface {
+	GetNotifications(ctx *gin.Context)
+	MarkNotificationsRead(ctx *gin.Context)
+	MarkAllNotificationsRead(ctx *gin.Context)
+}
+
+type notificationHandlerImpl struct {
+	svc service.NotificationService
+}
+
+func NewNotificationHandler(svc service.NotificationService) NotificationHandler {
+	return &notificationHandlerImpl{svc: svc}
+}
+
+// Get Notifications godoc
+//
+// @Summary		Get notifications of the login user
+// @Description	Return the newest notifications first, similar notifications of the same page are grouped together. The limit counts notifications, not groups, and a group can continue on the next page
+// @Tags		notification
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		limit	query		int		false	"Notifications per page, default 20, maximum 100"
+// @Param		cursor	query		string	false	"next_cursor of the previous page"
+// @Success		200		{object}	model.NotificationList
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/notifications [get]
+func (n *notificationHandlerImpl) GetNotifications(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	params, err := pagination.FromQuery(ctx.Request.URL.Query(), pagination.SortNewest, pagination.SortNewest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	notifications, err := n.svc.GetNotifications(ctx, userId, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, notifications)
+}
+
+// Mark Notifications Read godoc
+//
+// @Summary		Mark notifications as read
+// @Description	Only the notifications of the login user are updated
+// @Tags		notification
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		notifications	body	model.MarkNotificationsRead	true	"Notification ids"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/notifications/read [post]
+func (n *notificationHandlerImpl) MarkNotificationsRead(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	readData := model.MarkNotificationsRead{}
+	err = ctx.ShouldBindJSON(&readData)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(readData)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	err = n.svc.MarkNotificationsRead(ctx, userId, readData.NotificationIds)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "Notifications has been marked as read"})
+}
+
+// Mark All Notifications Read godoc
+//
+// @Summary		Mark every notification as read
+// @Description	Mark every unread notification of the login user as read
+// @Tags		notification
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/notifications/read-all [post]
+func (n *notificationHandlerImpl) MarkAllNotificationsRead(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	err = n.svc.MarkAllNotificationsRead(ctx, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "All notifications has been marked as read"})
+}
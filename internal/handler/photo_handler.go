@@ -1,19 +1,34 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator"
 	"github.com/zikri124/mygram-api/internal/model"
 	"github.com/zikri124/mygram-api/internal/service"
 	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/imaging"
+	"github.com/zikri124/mygram-api/pkg/pagination"
 	"github.com/zikri124/mygram-api/pkg/response"
 )
 
+const maxPhotoSize = 10 << 20
+
+// maxFormOverhead is the room left for the other form fields and the
+// multipart boundaries of an upload.
+const maxFormOverhead = 1 << 20
+
+func isBodyTooLarge(err error) bool {
+	maxBytesErr := &http.MaxBytesError{}
+	return errors.As(err, &maxBytesErr)
+}
+
 type PhotoHandler interface {
 	PostPhoto(ctx *gin.Context)
+	UploadPhoto(ctx *gin.Context)
 	GetAllPhotosByUserId(ctx *gin.Context)
 	GetPhotoById(ctx *gin.Context)
 	UpdatePhoto(ctx *gin.Context)
@@ -44,33 +59,119 @@ func NewPhotoHandler(svc service.PhotoService) PhotoHandler {
 func (p *photoHandlerImpl) PostPhoto(ctx *gin.Context) {
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	photoData := model.PhotoCreate{}
 	err = ctx.ShouldBindJSON(&photoData)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	validate := validator.New()
 	err = validate.Struct(photoData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	photo := model.Photo{}
-	photo.UserId = uint32(userId)
+	photo.UserId = userId
 	photo.Caption = photoData.Caption
 	photo.Title = photoData.Title
 	photo.PhotoUrl = photoData.PhotoUrl
 
 	photoRes, err := p.svc.PostPhoto(ctx, photo)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, photoRes)
+}
+
+// Upload Photo godoc
+//
+// @Summary		Upload photo
+// @Description	Upload an image file for the login user, EXIF and GPS metadata are removed before the image is stored
+// @Tags		photo
+// @Accept		multipart/form-data
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		photo				formData	file	true	"Image file (jpeg or png)"
+// @Param		title				formData	string	true	"Photo title"
+// @Param		caption				formData	string	false	"Photo caption"
+// @Param		keep_camera_info	formData	bool	false	"Keep camera make, model and exposure info"
+// @Success		201		{object}	model.PhotoResCreate
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/photos/upload [post]
+func (p *photoHandlerImpl) UploadPhoto(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	// the form is read into memory and temporary files, its size is limited
+	// before it is parsed
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxPhotoSize+maxFormOverhead)
+
+	uploadData := model.PhotoUpload{}
+	err = ctx.ShouldBind(&uploadData)
+	if isBodyTooLarge(err) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, response.NewErrorResponse(ctx, "photo file is too large, maximum size is 10MB"))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(uploadData)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("photo")
+	if isBodyTooLarge(err) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, response.NewErrorResponse(ctx, "photo file is too large, maximum size is 10MB"))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Missing photo file"))
+		return
+	}
+
+	if fileHeader.Size > maxPhotoSize {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "photo file is too large, maximum size is 10MB"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	defer file.Close()
+
+	image, err := io.ReadAll(io.LimitReader(file, maxPhotoSize))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	photoRes, err := p.svc.UploadPhoto(ctx, userId, uploadData, image)
+	if errors.Is(err, imaging.ErrUnsupportedFormat) || errors.Is(err, imaging.ErrImageTooLarge) {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -80,31 +181,40 @@ func (p *photoHandlerImpl) PostPhoto(ctx *gin.Context) {
 // Get Photo godoc
 //
 // @Summary		Get all data of a photo by user id
-// @Description	Return an array of photo data
+// @Description	Return a page of photo data, newest first by default
 // @Tags		photo
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header 	string	true "Bearer token"
 // @Param       user_id    query    string  false  "user id of the owner"
-// @Success		200		{object}	[]model.PhotoView
+// @Param		limit	query		int		false	"Photos per page, default 20, maximum 100"
+// @Param		sort	query		string	false	"newest, oldest, most_liked or most_commented"
+// @Param		cursor	query		string	false	"next_cursor of the previous page"
+// @Success		200		{object}	pagination.Page{data=[]model.PhotoView}
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/photos [get]
 func (p *photoHandlerImpl) GetAllPhotosByUserId(ctx *gin.Context) {
 	userIdStr := ctx.Request.URL.Query().Get("user_id")
 	if userIdStr == "" {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Missing User id in query"})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Missing User id in query"))
 		return
 	}
-	userId, err := strconv.Atoi(userIdStr)
+	userId, err := helper.ParseId(userIdStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	params, err := pagination.FromQuery(ctx.Request.URL.Query(), pagination.SortNewest, model.PhotoSorts...)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	photos, err := p.svc.GetAllPhotosByUserId(ctx, uint32(userId))
+	photos, err := p.svc.GetAllPhotosByUserId(ctx, userId, params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -119,21 +229,21 @@ func (p *photoHandlerImpl) GetAllPhotosByUserId(ctx *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header string	true "Bearer token"
-// @Param		id		path		int	true	"photo ID"
+// @Param		id		path		string	true	"photo ID"
 // @Success		200		{object}	model.PhotoView
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/photos/{id} [get]
 func (p *photoHandlerImpl) GetPhotoById(ctx *gin.Context) {
-	photoId, err := strconv.Atoi(ctx.Param("id"))
+	photoId, err := helper.ParseId(ctx.Param("id"))
 	if photoId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	photo, err := p.svc.GetPhotoById(ctx, uint32(photoId))
+	photo, err := p.svc.GetPhotoById(ctx, photoId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -148,64 +258,65 @@ func (p *photoHandlerImpl) GetPhotoById(ctx *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header string	true "Bearer token"
-// @Param		id		path		int	true	"Photo id"
+// @Param		id		path		string	true	"Photo id"
 // @Param		photo	body		model.UpdatePhoto	true	"New Photo Editted"
 // @Success		200		{object}	model.PhotoResUpdate
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/photos/{id} [put]
 func (p *photoHandlerImpl) UpdatePhoto(ctx *gin.Context) {
-	photoId, err := strconv.Atoi(ctx.Param("id"))
+	photoId, err := helper.ParseId(ctx.Param("id"))
 	if photoId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	photo, err := p.svc.GetPhotoById(ctx, uint32(photoId))
+	photo, err := p.svc.GetPhotoById(ctx, photoId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if photo.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Photo did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Photo did not exist"))
 		return
 	}
 
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	if userId != uint32(photo.UserId) {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized to do this request"})
+	if userId != photo.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
 		return
 	}
 
 	photoEditData := model.UpdatePhoto{}
 	err = ctx.ShouldBindJSON(&photoEditData)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	validate := validator.New()
 	err = validate.Struct(photoEditData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	photoUpdate := model.Photo{}
-	photoUpdate.ID = uint32(photoId)
+	photoUpdate.ID = photoId
+	photoUpdate.UserId = photo.UserId
 	photoUpdate.Title = photoEditData.Title
 	photoUpdate.Caption = photoEditData.Caption
 	photoUpdate.PhotoUrl = photoEditData.PhotoUrl
 
 	photoRes, err := p.svc.UpdatePhoto(ctx, photoUpdate)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -220,43 +331,43 @@ func (p *photoHandlerImpl) UpdatePhoto(ctx *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header string	true "Bearer token"
-// @Param		id		path		int	true	"Photo Id"
+// @Param		id		path		string	true	"Photo Id"
 // @Success		200		{object}	response.SuccessResponse
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/photos/{id} [delete]
 func (p *photoHandlerImpl) DeletePhoto(ctx *gin.Context) {
-	photoId, err := strconv.Atoi(ctx.Param("id"))
+	photoId, err := helper.ParseId(ctx.Param("id"))
 	if photoId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	photo, err := p.svc.GetPhotoById(ctx, uint32(photoId))
+	photo, err := p.svc.GetPhotoById(ctx, photoId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if photo.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Photo did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Photo did not exist"))
 		return
 	}
 
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	if userId != uint32(photo.UserId) {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized to do this request"})
+	if userId != photo.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
 		return
 	}
 
-	err = p.svc.DeletePhoto(ctx, uint32(photoId))
+	err = p.svc.DeletePhoto(ctx, photoId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+type ReactionHandler interface {
+	ReactToComment(ctx *gin.Context)
+	RemoveCommentReaction(ctx *gin.Context)
+	GetPhotoReactions(ctx *gin.Context)
+	ReactToPhoto(ctx *gin.Context)
+	RemovePhotoReaction(ctx *gin.Context)
+}
+
+type reactionHandlerImpl struct {
+	svc        service.ReactionService
+	commentSvc service.CommentService
+	photoSvc   service.PhotoService
+}
+
+func NewReactionHandler(svc service.ReactionService, commentSvc service.CommentService, photoSvc service.PhotoService) ReactionHandler {
+	return &reactionHandlerImpl{svc: svc, commentSvc: commentSvc, photoSvc: photoSvc}
+}
+
+// React To Comment godoc
+//
+// @Summary		Add or change the reaction of the login user on a comment
+// @Description	The emoji must be one of 👍 ❤️ 😂 😮 😢 🔥, a user has one reaction per comment
+// @Tags		reaction
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id			path		string	true	"Comment id"
+// @Param		reaction	body		model.SetReaction	true	"Reaction"
+// @Success		200		{object}	model.ReactionSummary
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/comments/{id}/reactions [put]
+func (r *reactionHandlerImpl) ReactToComment(ctx *gin.Context) {
+	target, ok := r.getCommentTarget(ctx)
+	if !ok {
+		return
+	}
+
+	r.react(ctx, target)
+}
+
+// Remove Comment Reaction godoc
+//
+// @Summary		Remove the reaction of the login user on a comment
+// @Description	Removing a reaction that does not exist is not an error
+// @Tags		reaction
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Comment id"
+// @Success		200		{object}	model.ReactionSummary
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/comments/{id}/reactions [delete]
+func (r *reactionHandlerImpl) RemoveCommentReaction(ctx *gin.Context) {
+	target, ok := r.getCommentTarget(ctx)
+	if !ok {
+		return
+	}
+
+	r.removeReaction(ctx, target)
+}
+
+// Get Photo Reactions godoc
+//
+// @Summary		Get the reactions of a photo
+// @Description	Return the count of every emoji and the reaction of the login user
+// @Tags		reaction
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Photo id"
+// @Success		200		{object}	model.ReactionSummary
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/photos/{id}/reactions [get]
+func (r *reactionHandlerImpl) GetPhotoReactions(ctx *gin.Context) {
+	target, ok := r.getPhotoTarget(ctx)
+	if !ok {
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	summaries, err := r.svc.GetReactionSummaries(ctx, target.Type, []publicid.ID{target.Id}, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summaries[target.Id])
+}
+
+// React To Photo godoc
+//
+// @Summary		Add or change the reaction of the login user on a photo
+// @Description	The emoji must be one of 👍 ❤️ 😂 😮 😢 🔥, a user has one reaction per photo
+// @Tags		reaction
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id			path		string	true	"Photo id"
+// @Param		reaction	body		model.SetReaction	true	"Reaction"
+// @Success		200		{object}	model.ReactionSummary
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/photos/{id}/reactions [put]
+func (r *reactionHandlerImpl) ReactToPhoto(ctx *gin.Context) {
+	target, ok := r.getPhotoTarget(ctx)
+	if !ok {
+		return
+	}
+
+	r.react(ctx, target)
+}
+
+// Remove Photo Reaction godoc
+//
+// @Summary		Remove the reaction of the login user on a photo
+// @Description	Removing a reaction that does not exist is not an error
+// @Tags		reaction
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Photo id"
+// @Success		200		{object}	model.ReactionSummary
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/photos/{id}/reactions [delete]
+func (r *reactionHandlerImpl) RemovePhotoReaction(ctx *gin.Context) {
+	target, ok := r.getPhotoTarget(ctx)
+	if !ok {
+		return
+	}
+
+	r.removeReaction(ctx, target)
+}
+
+func (r *reactionHandlerImpl) react(ctx *gin.Context, target model.ReactionTarget) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	newReaction := model.SetReaction{}
+	err = ctx.ShouldBindJSON(&newReaction)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(newReaction)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	summary, err := r.svc.React(ctx, target, userId, newReaction.Emoji)
+	if errors.Is(err, service.ErrInvalidReactionEmoji) {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+func (r *reactionHandlerImpl) removeReaction(ctx *gin.Context, target model.ReactionTarget) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	summary, err := r.svc.RemoveReaction(ctx, target, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+func (r *reactionHandlerImpl) getCommentTarget(ctx *gin.Context) (model.ReactionTarget, bool) {
+	commentId, err := helper.ParseId(ctx.Param("id"))
+	if commentId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid comment id"))
+		return model.ReactionTarget{}, false
+	}
+
+	comment, err := r.commentSvc.GetCommentById(ctx, commentId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return model.ReactionTarget{}, false
+	}
+
+	if comment.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Comment did not exist"))
+		return model.ReactionTarget{}, false
+	}
+
+	return model.ReactionTarget{Type: model.ReactionTargetComment, Id: comment.ID, OwnerId: comment.UserId, PhotoId: comment.PhotoId}, true
+}
+
+func (r *reactionHandlerImpl) getPhotoTarget(ctx *gin.Context) (model.ReactionTarget, bool) {
+	photoId, err := helper.ParseId(ctx.Param("id"))
+	if photoId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid photo id"))
+		return model.ReactionTarget{}, false
+	}
+
+	photo, err := r.photoSvc.GetPhotoById(ctx, photoId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return model.ReactionTarget{}, false
+	}
+
+	if photo.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Photo did not exist"))
+		return model.ReactionTarget{}, false
+	}
+
+	return model.ReactionTarget{Type: model.ReactionTargetPhoto, Id: photo.ID, OwnerId: photo.UserId, PhotoId: photo.ID}, true
+}
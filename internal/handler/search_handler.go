@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+const maxSearchQueryLength = 100
+
+type SearchHandler interface {
+	Search(ctx *gin.Context)
+}
+
+type searchHandlerImpl struct {
+	svc service.SearchService
+}
+
+func NewSearchHandler(svc service.SearchService) SearchHandler {
+	return &searchHandlerImpl{svc: svc}
+}
+
+// Search godoc
+//
+// @Summary		Search users, photos and comments
+// @Description	Full-text search ranked by relevance, every word of q is matched as a prefix. Photos match on title, caption and hashtags
+// @Tags		search
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		q		query		string	true	"Search text, maximum 100 characters"
+// @Param		type	query		string	false	"Comma separated types to search among user, photo and comment, default user,photo"
+// @Param		limit	query		int		false	"Results per type, default 10, maximum 50"
+// @Success		200		{object}	model.SearchRes
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/search [get]
+func (s *searchHandlerImpl) Search(ctx *gin.Context) {
+	query := strings.TrimSpace(ctx.Query("q"))
+	if query == "" {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Missing search query"))
+		return
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "search query must be at most 100 characters"))
+		return
+	}
+
+	searchTypes := strings.Split(ctx.DefaultQuery("type", model.SearchTypeUser+","+model.SearchTypePhoto), ",")
+	for _, searchType := range searchTypes {
+		if !model.IsValidSearchType(searchType) {
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "type must be among "+strings.Join(model.SearchTypes, ", ")))
+			return
+		}
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 50 {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "limit must be between 1 and 50"))
+		return
+	}
+
+	searchRes, err := s.svc.Search(ctx, query, searchTypes, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, searchRes)
+}
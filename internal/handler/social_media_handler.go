@@ -2,13 +2,14 @@ package handler
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator"
 	"github.com/zikri124/mygram-api/internal/model"
 	"github.com/zikri124/mygram-api/internal/service"
 	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 	"github.com/zikri124/mygram-api/pkg/response"
 )
 
@@ -21,11 +22,12 @@ type SocialMediaHandler interface {
 }
 
 type socialMediaHandlerImpl struct {
-	svc service.SocialMediaService
+	svc     service.SocialMediaService
+	userSvc service.UserService
 }
 
-func NewSocialMediaHandler(svc service.SocialMediaService) SocialMediaHandler {
-	return &socialMediaHandlerImpl{svc: svc}
+func NewSocialMediaHandler(svc service.SocialMediaService, userSvc service.UserService) SocialMediaHandler {
+	return &socialMediaHandlerImpl{svc: svc, userSvc: userSvc}
 }
 
 // Create Social Media godoc
@@ -44,27 +46,27 @@ func NewSocialMediaHandler(svc service.SocialMediaService) SocialMediaHandler {
 func (s *socialMediaHandlerImpl) PostSocialMedia(ctx *gin.Context) {
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	newSocial := model.NewSocialMedia{}
 	err = ctx.ShouldBindJSON(&newSocial)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	validate := validator.New()
 	err = validate.Struct(newSocial)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	socialMediaRes, err := s.svc.PostSocial(ctx, userId, newSocial)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -74,31 +76,51 @@ func (s *socialMediaHandlerImpl) PostSocialMedia(ctx *gin.Context) {
 // Get Social Media godoc
 //
 // @Summary		Get all data of a social_media by user id
-// @Description	Return an array of social_media data
+// @Description	Return a page of social_media data, newest first by default. The page is empty when the owner made their social medias private
 // @Tags		social_media
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header 	string	true "Bearer token"
 // @Param       user_id    query    string  false  "user id of the owner"
-// @Success		200		{object}	[]model.SocialMediaView
+// @Param		limit	query		int		false	"Social medias per page, default 20, maximum 100"
+// @Param		sort	query		string	false	"newest or oldest"
+// @Param		cursor	query		string	false	"next_cursor of the previous page"
+// @Success		200		{object}	pagination.Page{data=[]model.SocialMediaView}
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/social_medias [get]
 func (s *socialMediaHandlerImpl) GetAllSocialMediasByUserId(ctx *gin.Context) {
 	userIdStr := ctx.Request.URL.Query().Get("user_id")
 	if userIdStr == "" {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Missing User id in query"})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Missing User id in query"))
 		return
 	}
-	userId, err := strconv.Atoi(userIdStr)
+	userId, err := helper.ParseId(userIdStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	socials, err := s.svc.GetAllSocialMediasByUserId(ctx, uint32(userId))
+	params, err := pagination.FromQuery(ctx.Request.URL.Query(), pagination.SortNewest, model.SocialMediaSorts...)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	canSee, err := s.canSeeSocialMedias(ctx, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if !canSee {
+		ctx.JSON(http.StatusOK, pagination.Page{Data: []model.SocialMediaView{}, Limit: params.Limit})
+		return
+	}
+
+	socials, err := s.svc.GetAllSocialMediasByUserId(ctx, userId, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -113,26 +135,37 @@ func (s *socialMediaHandlerImpl) GetAllSocialMediasByUserId(ctx *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header string	true "Bearer token"
-// @Param		id		path		int	true	"Social Media ID"
+// @Param		id		path		string	true	"Social Media ID"
 // @Success		200		{object}	model.SocialMediaView
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/social_medias/{id} [get]
 func (s *socialMediaHandlerImpl) GetSocialMediaById(ctx *gin.Context) {
-	socialId, err := strconv.Atoi(ctx.Param("id"))
+	socialId, err := helper.ParseId(ctx.Param("id"))
 	if socialId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	social, err := s.svc.GetSocialById(ctx, uint32(socialId))
+	social, err := s.svc.GetSocialById(ctx, socialId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if social.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "User Social Media data did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "User Social Media data did not exist"))
+		return
+	}
+
+	canSee, err := s.canSeeSocialMedias(ctx, social.UserId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if !canSee {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "User Social Media data did not exist"))
 		return
 	}
 
@@ -147,52 +180,52 @@ func (s *socialMediaHandlerImpl) GetSocialMediaById(ctx *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header string	true "Bearer token"
-// @Param		id		path		int	true	"Photo id"
+// @Param		id		path		string	true	"Photo id"
 // @Param		social_media	body		model.NewSocialMedia	true	"New Social Media Editted"
 // @Success		200		{object}	model.UpdateSocialMediaRes
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/social_medias/{id} [put]
 func (s *socialMediaHandlerImpl) UpdateSocialMedia(ctx *gin.Context) {
-	socialId, err := strconv.Atoi(ctx.Param("id"))
+	socialId, err := helper.ParseId(ctx.Param("id"))
 	if socialId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	socialData, err := s.svc.GetSocialById(ctx, uint32(socialId))
+	socialData, err := s.svc.GetSocialById(ctx, socialId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if socialData.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "User Social Media data did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "User Social Media data did not exist"))
 		return
 	}
 
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	if userId != uint32(socialData.UserId) {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized to do this request"})
+	if userId != socialData.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
 		return
 	}
 
 	socialUpdateData := model.NewSocialMedia{}
 	err = ctx.ShouldBindJSON(&socialUpdateData)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	validate := validator.New()
 	err = validate.Struct(socialUpdateData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -202,7 +235,7 @@ func (s *socialMediaHandlerImpl) UpdateSocialMedia(ctx *gin.Context) {
 
 	socialMediaRes, err := s.svc.UpdateSocial(ctx, social)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -217,45 +250,61 @@ func (s *socialMediaHandlerImpl) UpdateSocialMedia(ctx *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header string	true "Bearer token"
-// @Param		id		path		int	true	"Social Media Id"
+// @Param		id		path		string	true	"Social Media Id"
 // @Success		200		{object}	response.SuccessResponse
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/social_medias/{id} [delete]
 func (s *socialMediaHandlerImpl) DeleteSocialMedia(ctx *gin.Context) {
-	socialId, err := strconv.Atoi(ctx.Param("id"))
+	socialId, err := helper.ParseId(ctx.Param("id"))
 	if socialId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	social, err := s.svc.GetSocialById(ctx, uint32(socialId))
+	social, err := s.svc.GetSocialById(ctx, socialId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if social.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "User Social Media data did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "User Social Media data did not exist"))
 		return
 	}
 
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	if userId != uint32(social.UserId) {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized to do this request"})
+	if userId != social.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
 		return
 	}
 
-	err = s.svc.DeleteSocial(ctx, uint32(socialId))
+	err = s.svc.DeleteSocial(ctx, socialId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "Your social media has been successfully deleted"})
 }
+
+// canSeeSocialMedias tell whether the login user can see the social medias of
+// userId according to the privacy settings of userId.
+func (s *socialMediaHandlerImpl) canSeeSocialMedias(ctx *gin.Context, userId publicid.ID) (bool, error) {
+	viewer := viewerFromCtx(ctx)
+	if viewer.CanSeePrivate(userId) {
+		return true, nil
+	}
+
+	privacy, err := s.userSvc.GetPrivacySettings(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return privacy.ShowSocialMedias, nil
+}
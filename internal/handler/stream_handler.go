@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+const (
+	maxStreamPhotos = 20
+	streamHeartbeat = 25 * time.Second
+)
+
+type StreamHandler interface {
+	Stream(ctx *gin.Context)
+}
+
+type streamHandlerImpl struct {
+	hub infrastructure.EventHub
+}
+
+func NewStreamHandler(hub infrastructure.EventHub) StreamHandler {
+	return &streamHandlerImpl{hub: hub}
+}
+
+// Stream godoc
+//
+// @Summary		Stream real-time events
+// @Description	Server-Sent Events stream of the login user notifications and of the new comments and likes of the viewed photos
+// @Tags		stream
+// @Produce		text/event-stream
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		photo_id	query	[]string	false	"Ids of the photos being viewed"	collectionFormat(multi)
+// @Success		200		{object}	infrastructure.Event
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/stream [get]
+func (s *streamHandlerImpl) Stream(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	photoIds := ctx.QueryArray("photo_id")
+	if len(photoIds) > maxStreamPhotos {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "too many photo_id, maximum "+strconv.Itoa(maxStreamPhotos)))
+		return
+	}
+
+	topics := []string{infrastructure.UserTopic(userId)}
+	for _, photoIdStr := range photoIds {
+		photoId, err := helper.ParseId(photoIdStr)
+		if err != nil || photoId < 1 {
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid photo_id"))
+			return
+		}
+		topics = append(topics, infrastructure.PhotoTopic(photoId))
+	}
+
+	subscription := s.hub.Subscribe(topics...)
+	defer subscription.Close()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	// the stream outlive the server write timeout, the heartbeat detect the
+	// dead clients instead
+	http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-subscription.Done:
+			return false
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent(event.Type, event.Data)
+			return true
+		case <-heartbeat.C:
+			// a comment line keeps the proxies from closing an idle stream
+			_, err := w.Write([]byte(": heartbeat\n\n"))
+			return err == nil
+		}
+	})
+}
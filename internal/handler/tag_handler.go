@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+type TagHandler interface {
+	GetPhotosByTag(ctx *gin.Context)
+	GetTrendingTags(ctx *gin.Context)
+}
+
+type tagHandlerImpl struct {
+	svc service.TagService
+}
+
+func NewTagHandler(svc service.TagService) TagHandler {
+	return &tagHandlerImpl{svc: svc}
+}
+
+// Get Tag Photos godoc
+//
+// @Summary		Get photos of a hashtag
+// @Description	Return a page of the photos having the hashtag in their caption, newest first by default
+// @Tags		tag
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		tag		path		string	true	"Hashtag without #"
+// @Param		limit	query		int		false	"Photos per page, default 20, maximum 100"
+// @Param		sort	query		string	false	"newest, oldest, most_liked or most_commented"
+// @Param		cursor	query		string	false	"next_cursor of the previous page"
+// @Success		200		{object}	model.TagPhotosRes{data=[]model.PhotoView}
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/tags/{tag}/photos [get]
+func (t *tagHandlerImpl) GetPhotosByTag(ctx *gin.Context) {
+	tag := ctx.Param("tag")
+	if tag == "" {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Missing tag"))
+		return
+	}
+
+	params, err := pagination.FromQuery(ctx.Request.URL.Query(), pagination.SortNewest, model.PhotoSorts...)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	tagPhotos, err := t.svc.GetPhotosByTag(ctx, tag, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tagPhotos)
+}
+
+// Get Trending Tags godoc
+//
+// @Summary		Get trending hashtags
+// @Description	Return the hashtags used the most in the last hours
+// @Tags		tag
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		hours	query		int		false	"Size of the time window in hours, default 24, maximum 168"
+// @Param		limit	query		int		false	"Number of tags, maximum 50"
+// @Success		200		{object}	[]model.TagView
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/tags/trending [get]
+func (t *tagHandlerImpl) GetTrendingTags(ctx *gin.Context) {
+	hours, err := strconv.Atoi(ctx.DefaultQuery("hours", "24"))
+	if err != nil || hours < 1 || hours > 168 {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "hours must be between 1 and 168"))
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 50 {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "limit must be between 1 and 50"))
+		return
+	}
+
+	tags, err := t.svc.GetTrendingTags(ctx, time.Duration(hours)*time.Hour, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
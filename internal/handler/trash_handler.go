@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+type TrashHandler interface {
+	GetTrash(ctx *gin.Context)
+	RestorePhoto(ctx *gin.Context)
+	RestoreComment(ctx *gin.Context)
+	RestoreSocialMedia(ctx *gin.Context)
+}
+
+type trashHandlerImpl struct {
+	svc service.TrashService
+}
+
+func NewTrashHandler(svc service.TrashService) TrashHandler {
+	return &trashHandlerImpl{svc: svc}
+}
+
+// Get Trash godoc
+//
+// @Summary		Show the recently deleted items of the login user
+// @Description	Photos, comments and social medias can be restored until their purge_at, the comments deleted with a photo are restored with the photo
+// @Tags		trash
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Success		200		{object}	[]model.TrashItem
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/trash [get]
+func (t *trashHandlerImpl) GetTrash(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	items, err := t.svc.GetTrash(ctx, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
+
+// Restore Photo godoc
+//
+// @Summary		Restore a deleted photo of the login user
+// @Description	The comments deleted with the photo are restored too
+// @Tags		trash
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Photo id"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		401		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/trash/photos/{id}/restore [post]
+func (t *trashHandlerImpl) RestorePhoto(ctx *gin.Context) {
+	t.restore(ctx, model.TrashTypePhoto, "Deleted photo did not exist")
+}
+
+// Restore Comment godoc
+//
+// @Summary		Restore a deleted comment of the login user
+// @Description	The comment cannot be restored while its photo is deleted
+// @Tags		trash
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Comment id"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		401		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		409		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/trash/comments/{id}/restore [post]
+func (t *trashHandlerImpl) RestoreComment(ctx *gin.Context) {
+	t.restore(ctx, model.TrashTypeComment, "Deleted comment did not exist")
+}
+
+// Restore Social Media godoc
+//
+// @Summary		Restore a deleted social media of the login user
+// @Description	Restore a deleted social media of the login user
+// @Tags		trash
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Social media id"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		401		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/trash/social_medias/{id}/restore [post]
+func (t *trashHandlerImpl) RestoreSocialMedia(ctx *gin.Context) {
+	t.restore(ctx, model.TrashTypeSocialMedia, "Deleted social media did not exist")
+}
+
+func (t *trashHandlerImpl) restore(ctx *gin.Context, itemType string, notFoundMessage string) {
+	itemId, err := helper.ParseId(ctx.Param("id"))
+	if itemId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Invalid id"))
+		return
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	item, err := t.svc.GetDeletedItem(ctx, itemType, itemId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if item.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, notFoundMessage))
+		return
+	}
+
+	if item.UserId != userId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+		return
+	}
+
+	err = t.svc.Restore(ctx, itemType, *item)
+	if errors.Is(err, service.ErrTrashPhotoDeleted) {
+		ctx.JSON(http.StatusConflict, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "the " + itemType + " has been restored"})
+}
@@ -1,19 +1,35 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator"
 	"github.com/zikri124/mygram-api/internal/model"
 	"github.com/zikri124/mygram-api/internal/service"
 	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/imaging"
 	"github.com/zikri124/mygram-api/pkg/response"
 )
 
+const maxAvatarSize = 5 << 20
+
 type UserHandler interface {
 	GetUserById(ctx *gin.Context)
+	GetMe(ctx *gin.Context)
+	GetUserByUsername(ctx *gin.Context)
+	UsernameAutocomplete(ctx *gin.Context)
+	GetUserProfile(ctx *gin.Context)
+	UpdateProfile(ctx *gin.Context)
+	UpdateAvatar(ctx *gin.Context)
+	RemoveAvatar(ctx *gin.Context)
+	GetPrivacySettings(ctx *gin.Context)
+	UpdatePrivacySettings(ctx *gin.Context)
 	UserRegister(ctx *gin.Context)
 	UserLogin(ctx *gin.Context)
 	UserEdit(ctx *gin.Context)
@@ -31,37 +47,370 @@ func NewUserHandler(svc service.UserService) UserHandler {
 // Show User by Id godoc
 //
 // @Summary		Show user data by user id
-// @Description	Show data of user by id given in params
+// @Description	The email is only shown to the user and the admins, the age only when the user made it public
 // @Tags		users
 // @Accept		json
 // @Produce		json
-// @Param		id		path		int		true	"User ID"
-// @Success		200		{object}	model.UserView
+// @Param		Authorization header 	string	false "Bearer token"
+// @Param		id		path		string		true	"User ID"
+// @Success		200		{object}	model.UserPublicView
 // @Failure		400		{object}	response.ErrorResponse
 // @Failure		404		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router			/v1/users/{id} [get]
 func (u *userHandlerImpl) GetUserById(ctx *gin.Context) {
-	userId, err := strconv.Atoi(ctx.Param("id"))
+	userId, err := helper.ParseId(ctx.Param("id"))
 	if userId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	user, err := u.svc.GetUserById(ctx, uint32(userId))
+	user, err := u.svc.GetPublicUserById(ctx, userId, viewerFromCtx(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if user.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "User not found"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "User not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// Show Me godoc
+//
+// @Summary		Show the data of the logged in user
+// @Description	Show the data of the logged in user including the private fields
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Success		200		{object}	model.UserView
+// @Failure		500		{object}	response.ErrorResponse
+// @Router			/v1/users/me [get]
+func (u *userHandlerImpl) GetMe(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	user, err := u.svc.GetUserById(ctx, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, user)
 }
 
+// Show User by Username godoc
+//
+// @Summary		Show user data by username
+// @Description	The username is case-insensitive, the email and the age are shown like in the show by id route
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	false "Bearer token"
+// @Param		username	path		string	true	"Username"
+// @Success		200		{object}	model.UserPublicView
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router			/v1/users/by-username/{username} [get]
+func (u *userHandlerImpl) GetUserByUsername(ctx *gin.Context) {
+	user, err := u.svc.GetUserByUsername(ctx, ctx.Param("username"), viewerFromCtx(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if user.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "User not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// Username Autocomplete godoc
+//
+// @Summary		Suggest usernames starting with a prefix
+// @Description	Case-insensitive prefix match for mention pickers, the exact match and the shortest usernames come first
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		q		query		string	true	"Username prefix, a leading @ is ignored"
+// @Param		limit	query		int		false	"Number of suggestions, default 10, maximum 20"
+// @Success		200		{object}	[]model.UserSuggestion
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router			/v1/users/autocomplete [get]
+func (u *userHandlerImpl) UsernameAutocomplete(ctx *gin.Context) {
+	prefix := strings.TrimPrefix(strings.TrimSpace(ctx.Query("q")), "@")
+	if prefix == "" {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Missing username prefix"))
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 20 {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "limit must be between 1 and 20"))
+		return
+	}
+
+	suggestions, err := u.svc.GetUsernameSuggestions(ctx, prefix, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestions)
+}
+
+// Show User Profile godoc
+//
+// @Summary		Show the public profile of an user
+// @Description	The profile include the photo and follow counts and the social media links of the user, the fields the user made private are only shown to the user and the admins
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	false "Bearer token"
+// @Param		id		path		string		true	"User ID"
+// @Success		200		{object}	model.UserProfile
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router			/v1/users/{id}/profile [get]
+func (u *userHandlerImpl) GetUserProfile(ctx *gin.Context) {
+	userId, err := helper.ParseId(ctx.Param("id"))
+	if userId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Invalid user id"))
+		return
+	}
+
+	profile, err := u.svc.GetUserProfile(ctx, userId, viewerFromCtx(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if profile.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "User not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// Update Profile godoc
+//
+// @Summary		Update the profile of the logged in user
+// @Description	Only the fields present in the body are changed, an empty string clear the field. The website must be an http or https url
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		profile	body		model.UpdateProfile	true	"Profile fields"
+// @Success		200		{object}	model.UserProfile
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router			/v1/users/me/profile [patch]
+func (u *userHandlerImpl) UpdateProfile(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	profileData := model.UpdateProfile{}
+	err = ctx.ShouldBindJSON(&profileData)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(profileData)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if profileData.Website != nil && *profileData.Website != "" {
+		website, err := url.Parse(*profileData.Website)
+		if err != nil || (website.Scheme != "http" && website.Scheme != "https") || website.Host == "" {
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "website must be an http or https url"))
+			return
+		}
+	}
+
+	profile, err := u.svc.UpdateProfile(ctx, userId, profileData)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// Update Avatar godoc
+//
+// @Summary		Upload the avatar of the logged in user
+// @Description	The image is re-encoded without its metadata like the uploaded photos, maximum size is 5MB
+// @Tags		users
+// @Accept		mpfd
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		avatar	formData	file	true	"JPEG or PNG image"
+// @Success		200		{object}	model.UserProfile
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router			/v1/users/me/avatar [put]
+func (u *userHandlerImpl) UpdateAvatar(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	// the form is read into memory and temporary files, its size is limited
+	// before it is parsed
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxAvatarSize+maxFormOverhead)
+
+	fileHeader, err := ctx.FormFile("avatar")
+	if isBodyTooLarge(err) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, response.NewErrorResponse(ctx, "avatar file is too large, maximum size is 5MB"))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "Missing avatar file"))
+		return
+	}
+
+	if fileHeader.Size > maxAvatarSize {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "avatar file is too large, maximum size is 5MB"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	defer file.Close()
+
+	image, err := io.ReadAll(io.LimitReader(file, maxAvatarSize))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	profile, err := u.svc.UpdateAvatar(ctx, userId, image)
+	if errors.Is(err, imaging.ErrUnsupportedFormat) || errors.Is(err, imaging.ErrImageTooLarge) {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// Remove Avatar godoc
+//
+// @Summary		Remove the avatar of the logged in user
+// @Description	Remove the avatar of the logged in user
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Success		200		{object}	model.UserProfile
+// @Failure		500		{object}	response.ErrorResponse
+// @Router			/v1/users/me/avatar [delete]
+func (u *userHandlerImpl) RemoveAvatar(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	profile, err := u.svc.RemoveAvatar(ctx, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// Show Privacy Settings godoc
+//
+// @Summary		Show the privacy settings of the logged in user
+// @Description	The settings tell which profile fields the other users can see
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Success		200		{object}	model.PrivacySettings
+// @Failure		500		{object}	response.ErrorResponse
+// @Router			/v1/users/me/privacy [get]
+func (u *userHandlerImpl) GetPrivacySettings(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	privacy, err := u.svc.GetPrivacySettings(ctx, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, privacy)
+}
+
+// Update Privacy Settings godoc
+//
+// @Summary		Update the privacy settings of the logged in user
+// @Description	Only the settings present in the body are changed
+// @Tags		users
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		privacy	body		model.UpdatePrivacySettings	true	"Privacy settings"
+// @Success		200		{object}	model.PrivacySettings
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router			/v1/users/me/privacy [patch]
+func (u *userHandlerImpl) UpdatePrivacySettings(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	privacyData := model.UpdatePrivacySettings{}
+	err = ctx.ShouldBindJSON(&privacyData)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	privacy, err := u.svc.UpdatePrivacySettings(ctx, userId, privacyData)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, privacy)
+}
+
 // Register User godoc
 //
 // @Summary		Register a new user
@@ -72,6 +421,7 @@ func (u *userHandlerImpl) GetUserById(ctx *gin.Context) {
 // @Param		user	body	model.UserSignUp	true	"New User"
 // @Success		200		{object}	model.UserView
 // @Failure		400		{object}	response.ErrorResponse
+// @Failure		409		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/users/register [post]
 func (u *userHandlerImpl) UserRegister(ctx *gin.Context) {
@@ -79,31 +429,39 @@ func (u *userHandlerImpl) UserRegister(ctx *gin.Context) {
 
 	err := ctx.ShouldBindJSON(&userRegData)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	validate := validator.New()
 	err = validate.Struct(userRegData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	isValidAge, err := u.svc.CheckIsAValidAge(userRegData.DOB)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if !isValidAge {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "user age must above 8"})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "user age must above 8"))
 		return
 	}
 
 	user, err := u.svc.UserRegister(ctx, userRegData)
+	if errors.Is(err, service.ErrUsernameReserved) {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	if errors.Is(err, service.ErrUsernameTaken) {
+		ctx.JSON(http.StatusConflict, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -126,26 +484,26 @@ func (u *userHandlerImpl) UserLogin(ctx *gin.Context) {
 	userData := model.UserSignIn{}
 	err := ctx.ShouldBindJSON(&userData)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	validate := validator.New()
 	err = validate.Struct(userData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	user, err := u.svc.UserLogin(ctx, userData)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	token, err := u.svc.GenerateAccessToken(ctx, *user)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -160,49 +518,58 @@ func (u *userHandlerImpl) UserLogin(ctx *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		Authorization header 	string	true "bearer token"
-// @Param		id		path		int	true	"Edit User"
+// @Param		id		path		string	true	"Edit User"
 // @Param		user	body		model.UserEdit	true	"New User"
 // @Success		200		{object}	model.UserView
 // @Failure		400		{object}	response.ErrorResponse
+// @Failure		409		{object}	response.ErrorResponse
 // @Failure		500		{object}	response.ErrorResponse
 // @Router		/v1/users/{id} [put]
 func (u *userHandlerImpl) UserEdit(ctx *gin.Context) {
-	userId, err := strconv.Atoi(ctx.Param("id"))
+	userId, err := helper.ParseId(ctx.Param("id"))
 	if userId == 0 || err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	userIdFromToken, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	if uint32(userId) != userIdFromToken {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "does not have access to edit other user's data"})
+	if userId != userIdFromToken {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "does not have access to edit other user's data"))
 		return
 	}
 
 	userEditData := model.UserEdit{}
 	err = ctx.ShouldBindJSON(&userEditData)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	validate := validator.New()
 	err = validate.Struct(userEditData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	user := model.User{ID: uint32(userId), Username: userEditData.Username, Email: userEditData.Email}
+	user := model.User{ID: userId, Username: userEditData.Username, Email: userEditData.Email}
 
 	userRes, err := u.svc.EditUser(ctx, user)
+	if errors.Is(err, service.ErrUsernameReserved) {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+	if errors.Is(err, service.ErrUsernameTaken) {
+		ctx.JSON(http.StatusConflict, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
@@ -211,8 +578,8 @@ func (u *userHandlerImpl) UserEdit(ctx *gin.Context) {
 
 // Delete User godoc
 //
-// @Summary		Delete an user by user id
-// @Description	User only can delete their own account
+// @Summary		Delete the account of the login user
+// @Description	The account and the content of the user are hidden at once, logging in during the next 30 days restore them, after that they are deleted for good
 // @Tags		users
 // @Accept		json
 // @Produce		json
@@ -224,26 +591,33 @@ func (u *userHandlerImpl) UserEdit(ctx *gin.Context) {
 func (u *userHandlerImpl) UserDelete(ctx *gin.Context) {
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	user, err := u.svc.GetUserById(ctx, uint32(userId))
+	user, err := u.svc.GetUserById(ctx, userId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
 	if user.ID == 0 {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Message: "User did not exist"})
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "User did not exist"))
 		return
 	}
 
-	err = u.svc.DeleteUser(ctx, uint32(userId))
+	err = u.svc.DeleteUser(ctx, userId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "your account has been successfully deleted"})
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "your account has been deleted, log in within 30 days to restore it"})
+}
+
+// viewerFromCtx return the logged in user, the viewer is anonymous on the
+// routes where the authentication is optional.
+func viewerFromCtx(ctx *gin.Context) model.Viewer {
+	userId, _ := helper.GetUserIdFromGinCtx(ctx)
+	return model.Viewer{ID: userId, Role: ctx.GetString("UserRole")}
 }
@@ -3,6 +3,7 @@ package handler
 import (
 	"bytes"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,7 +11,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
 	"github.com/zikri124/mygram-api/internal/service/mocks"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 )
 
 func TestUserRegister(t *testing.T) {
@@ -98,4 +101,98 @@ func TestUserRegister(t *testing.T) {
 
 		assert.Equal(t, http.StatusCreated, rec.Result().StatusCode)
 	})
+
+	t.Run("register with a username already taken", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/register", bytes.NewBuffer([]byte(`{"username":"Test", "email":"test@test.com", "password":"test", "dob":"2010-10-04"}`)))
+
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		g, _ := gin.CreateTestContext(rec)
+		g.Request = req
+
+		userRegData := model.UserSignUp{Username: "Test", Email: "test@test.com", Password: "test", DOB: "2010-10-04"}
+
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.
+			On("CheckIsAValidAge", userRegData.DOB).
+			Return(true, nil)
+
+		serviceMock.
+			On("UserRegister", g, userRegData).
+			Return(nil, service.ErrUsernameTaken)
+
+		userHandler := userHandlerImpl{svc: serviceMock}
+		userHandler.UserRegister(g)
+
+		assert.Equal(t, http.StatusConflict, rec.Result().StatusCode)
+	})
+}
+
+func TestUpdateProfile(t *testing.T) {
+	t.Run("website is not an http url", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+
+		req := httptest.NewRequest(http.MethodPatch, "/v1/users/me/profile", bytes.NewBuffer([]byte(`{"bio":"hello", "website":"javascript:alert(1)"}`)))
+
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		g, _ := gin.CreateTestContext(rec)
+		g.Request = req
+		g.Set("UserId", float64(1))
+
+		userHandler := userHandlerImpl{}
+		userHandler.UpdateProfile(g)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Result().StatusCode)
+	})
+
+	t.Run("empty website clear it", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+
+		req := httptest.NewRequest(http.MethodPatch, "/v1/users/me/profile", bytes.NewBuffer([]byte(`{"website":""}`)))
+
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		g, _ := gin.CreateTestContext(rec)
+		g.Request = req
+		g.Set("UserId", float64(1))
+
+		website := ""
+
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.
+			On("UpdateProfile", g, publicid.ID(1), model.UpdateProfile{Website: &website}).
+			Return(&model.UserProfile{ID: 1}, nil)
+
+		userHandler := userHandlerImpl{svc: serviceMock}
+		userHandler.UpdateProfile(g)
+
+		assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
+
+func TestUpdateAvatar(t *testing.T) {
+	t.Run("body over the limit is refused before being read", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+
+		body := bytes.Buffer{}
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("avatar", "avatar.png")
+		part.Write(bytes.Repeat([]byte{0}, maxAvatarSize+maxFormOverhead))
+		form.Close()
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/users/me/avatar", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		rec := httptest.NewRecorder()
+		g, _ := gin.CreateTestContext(rec)
+		g.Request = req
+		g.Set("UserId", float64(1))
+
+		userHandler := userHandlerImpl{}
+		userHandler.UpdateAvatar(g)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Result().StatusCode)
+	})
 }
@@ -0,0 +1,359 @@
+package handler
+
+import (
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/service"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/response"
+	"github.com/zikri124/mygram-api/pkg/safehttp"
+)
+
+type WebhookHandler interface {
+	PostWebhook(ctx *gin.Context)
+	GetWebhooks(ctx *gin.Context)
+	GetWebhookById(ctx *gin.Context)
+	UpdateWebhook(ctx *gin.Context)
+	DeleteWebhook(ctx *gin.Context)
+	GetDeliveries(ctx *gin.Context)
+	Redeliver(ctx *gin.Context)
+}
+
+type webhookHandlerImpl struct {
+	svc service.WebhookService
+}
+
+func NewWebhookHandler(svc service.WebhookService) WebhookHandler {
+	return &webhookHandlerImpl{svc: svc}
+}
+
+// Create Webhook godoc
+//
+// @Summary		Create webhook
+// @Description	Subscribe an url to events of the login user (photo.created, comment.created, user.deleted), the secret used to sign the payloads is only returned once
+// @Tags		webhook
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Param		webhook	body		model.CreateWebhook	true	"New Webhook"
+// @Success		201		{object}	model.WebhookCreateRes
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/webhooks [post]
+func (w *webhookHandlerImpl) PostWebhook(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	newWebhook := model.CreateWebhook{}
+	err = ctx.ShouldBindJSON(&newWebhook)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(newWebhook)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	message := validateWebhook(newWebhook.Url, newWebhook.Events)
+	if message != "" {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, message))
+		return
+	}
+
+	webhookRes, err := w.svc.CreateWebhook(ctx, userId, newWebhook)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, webhookRes)
+}
+
+// Get Webhooks godoc
+//
+// @Summary		Get webhooks of the login user
+// @Description	Return an array of webhook data, the secrets are not included
+// @Tags		webhook
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header 	string	true "Bearer token"
+// @Success		200		{object}	[]model.WebhookRes
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/webhooks [get]
+func (w *webhookHandlerImpl) GetWebhooks(ctx *gin.Context) {
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	webhooks, err := w.svc.GetWebhooksByUserId(ctx, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+// Get Webhook godoc
+//
+// @Summary		Get data of a webhook by webhook id
+// @Description	Only the owner of the webhook can see it
+// @Tags		webhook
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Webhook ID"
+// @Success		200		{object}	model.WebhookRes
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/webhooks/{id} [get]
+func (w *webhookHandlerImpl) GetWebhookById(ctx *gin.Context) {
+	webhook, ok := w.getOwnedWebhook(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, service.ToWebhookRes(*webhook))
+}
+
+// Edit Webhook godoc
+//
+// @Summary		Edit webhook data by webhook id
+// @Description	Edit url, events and active state of a webhook
+// @Tags		webhook
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Webhook id"
+// @Param		webhook	body		model.UpdateWebhook	true	"New Webhook Editted"
+// @Success		200		{object}	model.WebhookRes
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/webhooks/{id} [put]
+func (w *webhookHandlerImpl) UpdateWebhook(ctx *gin.Context) {
+	webhook, ok := w.getOwnedWebhook(ctx)
+	if !ok {
+		return
+	}
+
+	webhookEditData := model.UpdateWebhook{}
+	err := ctx.ShouldBindJSON(&webhookEditData)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(webhookEditData)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	message := validateWebhook(webhookEditData.Url, webhookEditData.Events)
+	if message != "" {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, message))
+		return
+	}
+
+	webhookUpdate := model.Webhook{ID: webhook.ID, UserId: webhook.UserId, CreatedAt: webhook.CreatedAt}
+	webhookUpdate.Url = webhookEditData.Url
+	webhookUpdate.Events = webhookEditData.Events
+	webhookUpdate.Active = webhookEditData.Active
+
+	webhookRes, err := w.svc.UpdateWebhook(ctx, webhookUpdate)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhookRes)
+}
+
+// Delete Webhook godoc
+//
+// @Summary		Delete a webhook
+// @Description	Delete by id, the pending deliveries of the webhook are not sent anymore
+// @Tags		webhook
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Webhook Id"
+// @Success		200		{object}	response.SuccessResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/webhooks/{id} [delete]
+func (w *webhookHandlerImpl) DeleteWebhook(ctx *gin.Context) {
+	webhook, ok := w.getOwnedWebhook(ctx)
+	if !ok {
+		return
+	}
+
+	err := w.svc.DeleteWebhook(ctx, webhook.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SuccessResponse{Message: "Your webhook has been successfully deleted"})
+}
+
+// Get Webhook Deliveries godoc
+//
+// @Summary		Get the delivery log of a webhook
+// @Description	Return the newest deliveries first with their status, attempts and last response
+// @Tags		webhook
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id		path		string	true	"Webhook id"
+// @Param		limit	query		int		false	"Deliveries per page, default 20, maximum 100"
+// @Param		cursor	query		string	false	"next_cursor of the previous page"
+// @Success		200		{object}	pagination.Page{data=[]model.WebhookDelivery}
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/webhooks/{id}/deliveries [get]
+func (w *webhookHandlerImpl) GetDeliveries(ctx *gin.Context) {
+	webhook, ok := w.getOwnedWebhook(ctx)
+	if !ok {
+		return
+	}
+
+	params, err := pagination.FromQuery(ctx.Request.URL.Query(), pagination.SortNewest, pagination.SortNewest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	deliveries, err := w.svc.GetDeliveries(ctx, webhook.ID, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// Redeliver Webhook godoc
+//
+// @Summary		Redeliver a webhook delivery
+// @Description	Queue a new delivery with the same payload as the given delivery
+// @Tags		webhook
+// @Accept		json
+// @Produce		json
+// @Param		Authorization header string	true "Bearer token"
+// @Param		id			path		string	true	"Webhook id"
+// @Param		delivery_id	path		string	true	"Delivery id"
+// @Success		202		{object}	model.WebhookDelivery
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		500		{object}	response.ErrorResponse
+// @Router		/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (w *webhookHandlerImpl) Redeliver(ctx *gin.Context) {
+	webhook, ok := w.getOwnedWebhook(ctx)
+	if !ok {
+		return
+	}
+
+	deliveryId, err := helper.ParseId(ctx.Param("delivery_id"))
+	if deliveryId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid delivery id"))
+		return
+	}
+
+	delivery, err := w.svc.GetDeliveryById(ctx, deliveryId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	if delivery.ID == 0 || delivery.WebhookId != webhook.ID {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Delivery did not exist"))
+		return
+	}
+
+	redelivery, err := w.svc.Redeliver(ctx, *delivery)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, redelivery)
+}
+
+// getOwnedWebhook return the webhook of the id path param, the error response
+// is written and ok is false when it does not exist or is not owned by the
+// login user.
+func (w *webhookHandlerImpl) getOwnedWebhook(ctx *gin.Context) (webhook *model.Webhook, ok bool) {
+	webhookId, err := helper.ParseId(ctx.Param("id"))
+	if webhookId == 0 || err != nil {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid webhook id"))
+		return nil, false
+	}
+
+	webhook, err = w.svc.GetWebhookById(ctx, webhookId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return nil, false
+	}
+
+	if webhook.ID == 0 {
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse(ctx, "Webhook did not exist"))
+		return nil, false
+	}
+
+	userId, err := helper.GetUserIdFromGinCtx(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, err.Error()))
+		return nil, false
+	}
+
+	if userId != webhook.UserId {
+		ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized to do this request"))
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+func validateWebhook(webhookUrl string, events []string) string {
+	parsedUrl, err := url.Parse(webhookUrl)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") {
+		return "webhook url must be an http or https url"
+	}
+
+	// the addresses resolved from a host name are checked when delivering,
+	// the obvious private hosts are refused early
+	host := parsedUrl.Hostname()
+	if ip, err := netip.ParseAddr(host); (err == nil && !safehttp.IsPublic(ip)) || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return "webhook url must be a public address"
+	}
+
+	for _, event := range events {
+		if !model.IsValidWebhookEvent(event) {
+			return "unknown webhook event " + event
+		}
+	}
+
+	return ""
+}
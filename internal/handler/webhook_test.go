@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWebhook(t *testing.T) {
+	assert.Empty(t, validateWebhook("https://hooks.example.com/mygram", nil))
+	assert.NotEmpty(t, validateWebhook("ftp://hooks.example.com", nil))
+
+	for _, privateUrl := range []string{"http://127.0.0.1:8080", "http://169.254.169.254/latest/meta-data", "http://10.0.0.2", "http://[::1]/", "http://localhost:3000"} {
+		assert.Equal(t, "webhook url must be a public address", validateWebhook(privateUrl, nil), privateUrl)
+	}
+}
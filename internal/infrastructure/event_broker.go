@@ -0,0 +1,132 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const eventChannel = "mygram_events"
+
+type memoryBrokerImpl struct {
+	mu       sync.RWMutex
+	handlers map[int]func(message BrokerMessage)
+	nextId   int
+}
+
+// NewMemoryBroker return a broker delivering the events inside the current
+// process only, use it when a single API instance is running.
+func NewMemoryBroker() EventBroker {
+	return &memoryBrokerImpl{handlers: map[int]func(message BrokerMessage){}}
+}
+
+func (m *memoryBrokerImpl) Publish(ctx context.Context, message BrokerMessage) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, handle := range m.handlers {
+		handle(message)
+	}
+
+	return nil
+}
+
+func (m *memoryBrokerImpl) Listen(ctx context.Context, handle func(message BrokerMessage)) error {
+	m.mu.Lock()
+	id := m.nextId
+	m.nextId++
+	m.handlers[id] = handle
+	m.mu.Unlock()
+
+	<-ctx.Done()
+
+	m.mu.Lock()
+	delete(m.handlers, id)
+	m.mu.Unlock()
+
+	return nil
+}
+
+type postgresBrokerImpl struct {
+	db       GormPostgres
+	dbConfig DbConfig
+}
+
+// NewPostgresBroker return a broker sharing the events between every API
+// instance connected to the same database with LISTEN/NOTIFY, dbConfig is
+// used to open the listening connection.
+func NewPostgresBroker(db GormPostgres, dbConfig DbConfig) EventBroker {
+	return &postgresBrokerImpl{db: db, dbConfig: dbConfig}
+}
+
+const (
+	EventBrokerMemory   = "memory"
+	EventBrokerPostgres = "postgres"
+)
+
+// NewEventBroker return the broker named broker, EventBrokerMemory only
+// share the events within the instance.
+func NewEventBroker(db GormPostgres, dbConfig DbConfig, broker string) EventBroker {
+	if broker == EventBrokerPostgres {
+		return NewPostgresBroker(db, dbConfig)
+	}
+
+	return NewMemoryBroker()
+}
+
+// Publish inside a transaction is delivered by postgres on commit only.
+func (p *postgresBrokerImpl) Publish(ctx context.Context, message BrokerMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return p.db.GetConnection(ctx).WithContext(ctx).Exec("SELECT pg_notify(?, ?)", eventChannel, string(payload)).Error
+}
+
+// Listen keep a dedicated connection listening to the event channel and
+// reconnect when the connection is lost, until ctx is done.
+func (p *postgresBrokerImpl) Listen(ctx context.Context, handle func(message BrokerMessage)) error {
+	for ctx.Err() == nil {
+		err := p.listen(ctx, handle)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "event broker connection lost", "error", err)
+			time.Sleep(time.Second)
+		}
+	}
+
+	return nil
+}
+
+func (p *postgresBrokerImpl) listen(ctx context.Context, handle func(message BrokerMessage)) error {
+	conn, err := pgx.Connect(ctx, p.dbConfig.ConnectionString())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+eventChannel)
+	if err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		message := BrokerMessage{}
+		err = json.Unmarshal([]byte(notification.Payload), &message)
+		if err != nil {
+			slog.ErrorContext(ctx, "cannot decode event from broker", "error", err)
+			continue
+		}
+
+		handle(message)
+	}
+}
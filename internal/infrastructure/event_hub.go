@@ -0,0 +1,138 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+const subscriptionBufferSize = 32
+
+type Event struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+type BrokerMessage struct {
+	Topic string `json:"topic"`
+	Event Event  `json:"event"`
+}
+
+// EventBroker carry the events between the API instances, every message
+// published by any instance must be delivered to the Listen handler of every
+// instance (including the publisher).
+type EventBroker interface {
+	Publish(ctx context.Context, message BrokerMessage) error
+	Listen(ctx context.Context, handle func(message BrokerMessage)) error
+}
+
+type Subscription struct {
+	Events <-chan Event
+	// Done is closed when the hub is closed, the subscriber must stop.
+	Done  <-chan struct{}
+	close func()
+}
+
+func (s *Subscription) Close() {
+	s.close()
+}
+
+type EventHub interface {
+	Publish(ctx context.Context, topic string, eventType string, data any) error
+	Subscribe(topics ...string) *Subscription
+	Run(ctx context.Context) error
+	// Close signal every subscription to stop, the long lived streams must
+	// not hold the shutdown.
+	Close()
+}
+
+type eventHubImpl struct {
+	broker      EventBroker
+	mu          sync.RWMutex
+	subscribers map[string]map[chan Event]bool
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+func NewEventHub(broker EventBroker) EventHub {
+	return &eventHubImpl{broker: broker, subscribers: map[string]map[chan Event]bool{}, done: make(chan struct{})}
+}
+
+func UserTopic(userId publicid.ID) string {
+	return fmt.Sprintf("user:%d", userId)
+}
+
+func PhotoTopic(photoId publicid.ID) string {
+	return fmt.Sprintf("photo:%d", photoId)
+}
+
+func (e *eventHubImpl) Publish(ctx context.Context, topic string, eventType string, data any) error {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return e.broker.Publish(ctx, BrokerMessage{Topic: topic, Event: Event{Type: eventType, Data: rawData}})
+}
+
+func (e *eventHubImpl) Subscribe(topics ...string) *Subscription {
+	events := make(chan Event, subscriptionBufferSize)
+
+	e.mu.Lock()
+	for _, topic := range topics {
+		if e.subscribers[topic] == nil {
+			e.subscribers[topic] = map[chan Event]bool{}
+		}
+		e.subscribers[topic][events] = true
+	}
+	e.mu.Unlock()
+
+	once := sync.Once{}
+	return &Subscription{
+		Events: events,
+		Done:   e.done,
+		close: func() {
+			once.Do(func() {
+				e.mu.Lock()
+				for _, topic := range topics {
+					delete(e.subscribers[topic], events)
+					if len(e.subscribers[topic]) == 0 {
+						delete(e.subscribers, topic)
+					}
+				}
+				e.mu.Unlock()
+				close(events)
+			})
+		},
+	}
+}
+
+// Run deliver the events coming from the broker to the local subscribers
+// until ctx is done.
+func (e *eventHubImpl) Run(ctx context.Context) error {
+	return e.broker.Listen(ctx, e.dispatch)
+}
+
+func (e *eventHubImpl) Close() {
+	e.closeOnce.Do(func() {
+		close(e.done)
+	})
+}
+
+func (e *eventHubImpl) dispatch(message BrokerMessage) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for events := range e.subscribers[message.Topic] {
+		select {
+		case events <- message.Event:
+		default:
+			// a slow client must not block the other subscribers
+			slog.Warn("event dropped for a slow subscriber", "topic", message.Topic)
+		}
+	}
+}
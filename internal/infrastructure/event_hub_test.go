@@ -0,0 +1,29 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHubClose(t *testing.T) {
+	hub := NewEventHub(NewMemoryBroker())
+	subscription := hub.Subscribe(UserTopic(1))
+	defer subscription.Close()
+
+	require.NoError(t, hub.Publish(context.Background(), UserTopic(1), "notification", nil))
+
+	select {
+	case <-subscription.Done:
+		t.Fatal("subscription done before the hub is closed")
+	default:
+	}
+
+	hub.Close()
+	hub.Close()
+
+	_, ok := <-subscription.Done
+	assert.False(t, ok)
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	gorm "gorm.io/gorm"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,17 +15,35 @@ type GormPostgres struct {
 	mock.Mock
 }
 
-// GetConnection provides a mock function with given fields:
-func (_m *GormPostgres) GetConnection() *gorm.DB {
+// Close provides a mock function with given fields:
+func (_m *GormPostgres) Close() error {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetConnection provides a mock function with given fields: ctx
+func (_m *GormPostgres) GetConnection(ctx context.Context) *gorm.DB {
+	ret := _m.Called(ctx)
+
 	if len(ret) == 0 {
 		panic("no return value specified for GetConnection")
 	}
 
 	var r0 *gorm.DB
-	if rf, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gorm.DB)
@@ -33,6 +53,24 @@ func (_m *GormPostgres) GetConnection() *gorm.DB {
 	return r0
 }
 
+// Ping provides a mock function with given fields: ctx
+func (_m *GormPostgres) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewGormPostgres creates a new instance of GormPostgres. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewGormPostgres(t interface {
@@ -1,8 +1,9 @@
 package infrastructure
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
 	"gorm.io/driver/postgres"
@@ -10,50 +11,69 @@ import (
 )
 
 type DbConfig struct {
-	Host     string
-	User     string
-	Password string
-	DBName   string
-	Port     string
-	SSLMODE  string
+	Host     string `yaml:"host" env:"DB_HOST"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD"`
+	DBName   string `yaml:"name" env:"DB_NAME"`
+	Port     string `yaml:"port" env:"DB_PORT"`
+	SSLMODE  string `yaml:"sslmode" env:"DB_SSLMODE"`
 }
 
-func (dbConfig *DbConfig) Read() {
-	dbConfig.Host = os.Getenv("DB_HOST")
-	dbConfig.User = os.Getenv("DB_USER")
-	dbConfig.Password = os.Getenv("DB_PASSWORD")
-	dbConfig.DBName = os.Getenv("DB_NAME")
-	dbConfig.Port = os.Getenv("DB_PORT")
-	dbConfig.SSLMODE = os.Getenv("DB_SSLMODE")
+func (dbConfig *DbConfig) ConnectionString() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.SSLMODE)
 }
 
 type GormPostgres interface {
-	GetConnection() *gorm.DB
+	// GetConnection return the transaction of ctx if it has one (see
+	// TxManager), otherwise the shared connection.
+	GetConnection(ctx context.Context) *gorm.DB
+	// Ping check the database is reachable.
+	Ping(ctx context.Context) error
+	// Close the connection pool, once every query is done.
+	Close() error
 }
 
 type gormPostgresImpl struct {
 	master *gorm.DB
 }
 
-func NewGormPostgres() GormPostgres {
-	return &gormPostgresImpl{master: connect()}
+func NewGormPostgres(dbConfig DbConfig) GormPostgres {
+	return &gormPostgresImpl{master: connect(dbConfig)}
 }
 
-func connect() *gorm.DB {
-	var dbConfig = DbConfig{}
-	dbConfig.Read()
-
-	connectionStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.SSLMODE)
-
-	db, err := gorm.Open(postgres.Open(connectionStr), &gorm.Config{})
+func connect(dbConfig DbConfig) *gorm.DB {
+	db, err := gorm.Open(postgres.Open(dbConfig.ConnectionString()), &gorm.Config{Logger: gormLogger{}})
 
 	if err != nil {
-		log.Fatalln("DB error when connecting: ", err)
+		slog.Error("cannot connect to the database", "error", err)
+		os.Exit(1)
 	}
 
 	return db
 }
 
-func (g *gormPostgresImpl) GetConnection() *gorm.DB {
+func (g *gormPostgresImpl) GetConnection(ctx context.Context) *gorm.DB {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx
+	}
+
 	return g.master
 }
+
+func (g *gormPostgresImpl) Ping(ctx context.Context) error {
+	db, err := g.master.DB()
+	if err != nil {
+		return err
+	}
+
+	return db.PingContext(ctx)
+}
+
+func (g *gormPostgresImpl) Close() error {
+	db, err := g.master.DB()
+	if err != nil {
+		return err
+	}
+
+	return db.Close()
+}
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrFileNotInStorage = errors.New("file is not stored in this storage")
+
+type StorageConfig struct {
+	Dir     string `yaml:"dir" env:"UPLOAD_DIR"`
+	BaseUrl string `yaml:"base_url" env:"UPLOAD_BASE_URL"`
+	// ExportDir hold the personal data exports, it must not be served
+	// publicly.
+	ExportDir string `yaml:"export_dir" env:"EXPORT_DIR"`
+}
+
+type FileStorage interface {
+	Save(ctx context.Context, name string, data []byte) (url string, err error)
+	Read(ctx context.Context, url string) ([]byte, error)
+	Delete(ctx context.Context, url string) error
+}
+
+type localStorageImpl struct {
+	config StorageConfig
+}
+
+func NewLocalStorage(config StorageConfig) FileStorage {
+	return &localStorageImpl{config: config}
+}
+
+func (l *localStorageImpl) Save(ctx context.Context, name string, data []byte) (string, error) {
+	err := os.MkdirAll(l.config.Dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.WriteFile(filepath.Join(l.config.Dir, filepath.Base(name)), data, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(l.config.BaseUrl, "/") + "/" + filepath.Base(name), nil
+}
+
+func (l *localStorageImpl) Read(ctx context.Context, url string) ([]byte, error) {
+	path, err := l.path(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(path)
+}
+
+func (l *localStorageImpl) Delete(ctx context.Context, url string) error {
+	path, err := l.path(url)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// path return the file path of a url returned by Save.
+func (l *localStorageImpl) path(url string) (string, error) {
+	prefix := strings.TrimRight(l.config.BaseUrl, "/") + "/"
+	if !strings.HasPrefix(url, prefix) {
+		return "", ErrFileNotInStorage
+	}
+
+	return filepath.Join(l.config.Dir, filepath.Base(strings.TrimPrefix(url, prefix))), nil
+}
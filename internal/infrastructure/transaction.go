@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// txState is the transaction carried by the context with the functions to
+// run once it is committed.
+type txState struct {
+	tx          *gorm.DB
+	afterCommit []func(ctx context.Context)
+}
+
+// TxManager run several repository calls in one transaction, the transaction
+// is carried by the context so every GetConnection(ctx) inside fn use it.
+type TxManager interface {
+	// WithTx commit when fn return nil and rollback otherwise, a WithTx
+	// inside another one join the outer transaction.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txManagerImpl struct {
+	db GormPostgres
+}
+
+func NewTxManager(db GormPostgres) TxManager {
+	return &txManagerImpl{db: db}
+}
+
+func (t *txManagerImpl) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	state := &txState{}
+	err := t.db.GetConnection(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		state.tx = tx
+		return fn(context.WithValue(ctx, txKey{}, state))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range state.afterCommit {
+		fn(ctx)
+	}
+
+	return nil
+}
+
+// AfterCommit run fn once the transaction of ctx is committed, it is dropped
+// when the transaction is rolled back. Without transaction fn run right away.
+// It is meant for the side effects the other clients can see, e.g. the events
+// about the rows written by the transaction. fn is given a context outside of
+// the transaction.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	state, _ := ctx.Value(txKey{}).(*txState)
+	if state == nil {
+		fn(ctx)
+		return
+	}
+
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+func txFromContext(ctx context.Context) *gorm.DB {
+	state, _ := ctx.Value(txKey{}).(*txState)
+	if state == nil {
+		return nil
+	}
+
+	return state.tx
+}
@@ -0,0 +1,111 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newTxManagerMock(t *testing.T) (GormPostgres, TxManager, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	assert.Nil(t, err)
+
+	gormPostgres := &gormPostgresImpl{master: gormDB}
+	return gormPostgres, NewTxManager(gormPostgres), mock
+}
+
+func TestWithTx(t *testing.T) {
+	t.Run("commit when fn succeed", func(t *testing.T) {
+		gormPostgres, txManager, mock := newTxManagerMock(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := txManager.WithTx(context.Background(), func(ctx context.Context) error {
+			return gormPostgres.GetConnection(ctx).Exec("SELECT 1").Error
+		})
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when fn fail", func(t *testing.T) {
+		_, txManager, mock := newTxManagerMock(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		errFn := errors.New("fn failed")
+		err := txManager.WithTx(context.Background(), func(ctx context.Context) error {
+			return errFn
+		})
+
+		assert.Equal(t, errFn, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nested call join the outer transaction", func(t *testing.T) {
+		gormPostgres, txManager, mock := newTxManagerMock(t)
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		err := txManager.WithTx(context.Background(), func(outer context.Context) error {
+			return txManager.WithTx(outer, func(inner context.Context) error {
+				assert.Same(t, gormPostgres.GetConnection(outer), gormPostgres.GetConnection(inner))
+				return nil
+			})
+		})
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+	t.Run("after commit hooks run once the outer transaction is committed", func(t *testing.T) {
+		_, txManager, mock := newTxManagerMock(t)
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		ran := []string{}
+		err := txManager.WithTx(context.Background(), func(outer context.Context) error {
+			AfterCommit(outer, func(ctx context.Context) {
+				assert.Nil(t, txFromContext(ctx))
+				ran = append(ran, "outer")
+			})
+			return txManager.WithTx(outer, func(inner context.Context) error {
+				AfterCommit(inner, func(ctx context.Context) {
+					ran = append(ran, "inner")
+				})
+				assert.Empty(t, ran)
+				return nil
+			})
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"outer", "inner"}, ran)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("after commit hooks are dropped on rollback", func(t *testing.T) {
+		_, txManager, mock := newTxManagerMock(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		ran := false
+		err := txManager.WithTx(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func(ctx context.Context) {
+				ran = true
+			})
+			return errors.New("fn failed")
+		})
+
+		assert.NotNil(t, err)
+		assert.False(t, ran)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
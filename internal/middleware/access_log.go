@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+// AccessLog log every request once it is served, the server errors at error
+// and the client errors at warn.
+func AccessLog() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		slog.Log(ctx.Request.Context(), level, "request served",
+			"method", ctx.Request.Method,
+			"path", ctx.Request.URL.Path,
+			"route", ctx.FullPath(),
+			"status", status,
+			"latency_ms", time.Since(start).Milliseconds(),
+			"bytes", ctx.Writer.Size(),
+			"client_ip", ctx.ClientIP(),
+			"user_agent", ctx.Request.UserAgent(),
+		)
+	}
+}
+
+// Recovery answer 500 to a request whose handler panicked and log the panic
+// with its stack.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(ctx *gin.Context, err any) {
+		slog.ErrorContext(ctx.Request.Context(), "panic while serving request", "error", err, "stack", string(debug.Stack()))
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, "internal server error"))
+	})
+}
@@ -12,14 +12,16 @@ import (
 
 type Authorization interface {
 	CheckAuth(ctx *gin.Context)
+	OptionalAuth(ctx *gin.Context)
 }
 
 type authorizationImpl struct {
 	userService service.UserService
+	jwtSecret   string
 }
 
-func NewAuthorization(userService service.UserService) Authorization {
-	return &authorizationImpl{userService: userService}
+func NewAuthorization(userService service.UserService, jwtSecret string) Authorization {
+	return &authorizationImpl{userService: userService, jwtSecret: jwtSecret}
 }
 
 func (a *authorizationImpl) CheckAuth(ctx *gin.Context) {
@@ -27,25 +29,19 @@ func (a *authorizationImpl) CheckAuth(ctx *gin.Context) {
 
 	authArr := strings.Split(auth, " ")
 	if len(authArr) < 2 {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized",
-			Errors: []string{"invalid token"},
-		})
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized", "invalid token"))
 		return
 	}
 
 	if authArr[0] != "Bearer" {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized",
-			Errors: []string{"invalid token"},
-		})
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized", "invalid token"))
 		return
 	}
 
 	token := authArr[1]
-	claims, err := helper.ValidateToken(token)
+	claims, err := helper.ValidateToken(token, a.jwtSecret)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized",
-			Errors: []string{"invalid token", "failed to decode token"},
-		})
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized", "invalid token", "failed to decode token"))
 		return
 	}
 
@@ -53,22 +49,33 @@ func (a *authorizationImpl) CheckAuth(ctx *gin.Context) {
 
 	userId, err := helper.GetUserIdFromGinCtx(ctx)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, response.ErrorResponse{Message: "error when get user id from token"})
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, "error when get user id from token"))
 		return
 	}
 
-	user, err := a.userService.GetUserById(ctx, uint32(userId))
+	user, err := a.userService.GetUserById(ctx, userId)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, response.ErrorResponse{Message: "error when get user id from token"})
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, response.NewErrorResponse(ctx, "error when get user id from token"))
 		return
 	}
 
 	if user.ID == 0 {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response.ErrorResponse{Message: "unauthorized",
-			Errors: []string{"invalid token"},
-		})
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse(ctx, "unauthorized", "invalid token"))
 		return
 	}
 
+	ctx.Set("UserRole", user.Role)
+
 	ctx.Next()
 }
+
+// OptionalAuth let the anonymous requests through, a request with a token is
+// checked like in CheckAuth.
+func (a *authorizationImpl) OptionalAuth(ctx *gin.Context) {
+	if ctx.GetHeader("Authorization") == "" {
+		ctx.Next()
+		return
+	}
+
+	a.CheckAuth(ctx)
+}
@@ -1,13 +1,32 @@
 package middleware
 
-import "github.com/gin-gonic/gin"
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CorsConfig struct {
+	// AllowOrigins is the origins allowed to call the API, "*" allow any
+	// origin.
+	AllowOrigins []string `yaml:"allow_origins" env:"CORS_ALLOW_ORIGINS"`
+}
+
+func CorsMiddleware(config CorsConfig) gin.HandlerFunc {
+	allowAll := slices.Contains(config.AllowOrigins, "*")
 
-func CorsMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		origin := ctx.GetHeader("Origin")
+		if allowAll {
+			ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		} else if slices.Contains(config.AllowOrigins, origin) {
+			ctx.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			ctx.Writer.Header().Add("Vary", "Origin")
+		}
 		ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Request-ID")
+		ctx.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, Retry-After")
+		ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if ctx.Request.Method == "OPTIONS" {
 			ctx.AbortWithStatus(204)
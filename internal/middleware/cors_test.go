@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	g := gin.New()
+	g.Use(CorsMiddleware(CorsConfig{AllowOrigins: []string{"https://mygram.example"}}))
+	g.PATCH("/v1/users/me/privacy", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	t.Run("preflight allow the PATCH routes", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/users/me/privacy", nil)
+		req.Header.Set("Origin", "https://mygram.example")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://mygram.example", rec.Header().Get("Access-Control-Allow-Origin"))
+
+		methods := strings.Split(rec.Header().Get("Access-Control-Allow-Methods"), ", ")
+		assert.Contains(t, methods, http.MethodPatch)
+	})
+
+	t.Run("unknown origin is not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/users/me/privacy", nil)
+		req.Header.Set("Origin", "https://evil.example")
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+// bucketIdleTimeout is how long the bucket of a client without request is
+// kept, a new bucket is full anyway.
+const bucketIdleTimeout = 10 * time.Minute
+
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	// Rate is the requests per second allowed for each client IP.
+	Rate float64 `yaml:"rate" env:"RATE_LIMIT_RATE"`
+	// Burst is the requests a client can send at once.
+	Burst int `yaml:"burst" env:"RATE_LIMIT_BURST"`
+}
+
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+type rateLimiter struct {
+	mu        sync.Mutex
+	config    RateLimitConfig
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// RateLimit refuse with 429 the requests of a client IP over the token bucket
+// of config, every request pass through when it is disabled.
+func RateLimit(config RateLimitConfig) gin.HandlerFunc {
+	if !config.Enabled {
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}
+	}
+
+	limiter := &rateLimiter{config: config, buckets: map[string]*tokenBucket{}, now: time.Now}
+
+	return func(ctx *gin.Context) {
+		wait := limiter.take(ctx.ClientIP())
+		if wait > 0 {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, response.NewErrorResponse(ctx, "too many requests"))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// take consume a token of the bucket of key, it return how long to wait for
+// the next token when the bucket is empty.
+func (r *rateLimiter) take(key string) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.sweep(now)
+
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(r.config.Burst), lastSeen: now}
+		r.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(float64(r.config.Burst), bucket.tokens+now.Sub(bucket.lastSeen).Seconds()*r.config.Rate)
+	bucket.lastSeen = now
+
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / r.config.Rate * float64(time.Second))
+	}
+
+	bucket.tokens--
+	return 0
+}
+
+func (r *rateLimiter) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < bucketIdleTimeout {
+		return
+	}
+	r.lastSweep = now
+
+	for key, bucket := range r.buckets {
+		if now.Sub(bucket.lastSeen) > bucketIdleTimeout {
+			delete(r.buckets, key)
+		}
+	}
+}
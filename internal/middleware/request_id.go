@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/pkg/requestid"
+)
+
+// RequestId give every request an id, the X-Request-ID header of the client
+// or the proxy is kept when it is valid. The id is sent back in the response
+// header and put in the request context for the logs and the error
+// responses, the engine must have ContextWithFallback so the gin context
+// passed to the services resolve it.
+func RequestId() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		ctx.Header(requestid.Header, id)
+		ctx.Request = ctx.Request.WithContext(requestid.NewContext(ctx.Request.Context(), id))
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zikri124/mygram-api/pkg/requestid"
+	"github.com/zikri124/mygram-api/pkg/response"
+)
+
+func TestRequestId(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	g := gin.New()
+	g.ContextWithFallback = true
+	g.Use(RequestId())
+	g.GET("/fail", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse(ctx, "invalid"))
+	})
+
+	t.Run("client id is propagated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+		req.Header.Set(requestid.Header, "lb-42")
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, req)
+
+		body := response.ErrorResponse{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "lb-42", rec.Header().Get(requestid.Header))
+		assert.Equal(t, "lb-42", body.RequestId)
+	})
+
+	t.Run("invalid id is replaced", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+		req.Header.Set(requestid.Header, "forged\nline")
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, req)
+
+		body := response.ErrorResponse{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.NotEqual(t, "forged\nline", body.RequestId)
+		assert.True(t, requestid.Valid(body.RequestId))
+		assert.Equal(t, body.RequestId, rec.Header().Get(requestid.Header))
+	})
+}
@@ -0,0 +1,353 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"gorm.io/gorm"
+)
+
+// lockName is hashed to the key of the postgres advisory lock held while
+// migrating, so instances started together do not apply a migration twice.
+const lockName = "schema_migrations"
+
+//go:embed sql/*.sql
+var files embed.FS
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var ErrUnknownVersion = errors.New("unknown migration version")
+
+// LegacySchemaVersion is the last migration matching the schema of the
+// databases created before the migrations, the next ones must still run on
+// them, e.g. the one widening their 32-bit id columns.
+const LegacySchemaVersion = 4
+
+// ErrExistingSchema is returned when migrating a database which has tables
+// but no applied migration, it must be adopted with Baseline first.
+var ErrExistingSchema = fmt.Errorf("database already has tables but no applied migration, adopt it with migrate baseline %d first", LegacySchemaVersion)
+
+// Migration is a pair of sql/<version>_<name>.up.sql and .down.sql files.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+type Migrator interface {
+	// Up apply every pending migration and return the applied ones.
+	Up(ctx context.Context) ([]Migration, error)
+	// Down revert the last applied migration, nil when none is applied.
+	Down(ctx context.Context) (*Migration, error)
+	// To apply or revert migrations until version is the last applied one,
+	// version 0 revert everything.
+	To(ctx context.Context, version int64) ([]Migration, error)
+	Status(ctx context.Context) ([]MigrationStatus, error)
+	// Baseline record the migrations until version as applied without
+	// running them, to adopt a database created before the migrations.
+	Baseline(ctx context.Context, version int64) ([]Migration, error)
+}
+
+type migratorImpl struct {
+	db         infrastructure.GormPostgres
+	migrations []Migration
+}
+
+func NewMigrator(db infrastructure.GormPostgres) (Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &migratorImpl{db: db, migrations: migrations}, nil
+}
+
+// Load read the embedded migrations sorted by version, every version must
+// have both its up and down file.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version %s", entry.Name())
+		}
+
+		content, err := files.ReadFile("sql/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func (m *migratorImpl) Up(ctx context.Context) ([]Migration, error) {
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+func (m *migratorImpl) Down(ctx context.Context) (*Migration, error) {
+	var reverted *Migration
+
+	err := m.withLock(ctx, func(conn *gorm.DB, applied map[int64]schemaMigration) error {
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := applied[m.migrations[i].Version]; ok {
+				reverted = &m.migrations[i]
+				return revert(conn, *reverted)
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reverted, nil
+}
+
+func (m *migratorImpl) To(ctx context.Context, version int64) ([]Migration, error) {
+	if version != 0 && m.find(version) == nil {
+		return nil, ErrUnknownVersion
+	}
+
+	done := []Migration{}
+
+	err := m.withLock(ctx, func(conn *gorm.DB, applied map[int64]schemaMigration) error {
+		if len(applied) == 0 && version > 0 {
+			existing, err := hasTables(conn)
+			if err != nil {
+				return err
+			}
+			if existing {
+				return ErrExistingSchema
+			}
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && m.migrations[i].Version > version; i-- {
+			if _, ok := applied[m.migrations[i].Version]; !ok {
+				continue
+			}
+
+			err := revert(conn, m.migrations[i])
+			if err != nil {
+				return err
+			}
+			done = append(done, m.migrations[i])
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			err := apply(conn, migration)
+			if err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return done, nil
+}
+
+func (m *migratorImpl) Status(ctx context.Context) ([]MigrationStatus, error) {
+	statuses := []MigrationStatus{}
+
+	err := m.withLock(ctx, func(conn *gorm.DB, applied map[int64]schemaMigration) error {
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if schemaMigration, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &schemaMigration.AppliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func (m *migratorImpl) Baseline(ctx context.Context, version int64) ([]Migration, error) {
+	if m.find(version) == nil {
+		return nil, ErrUnknownVersion
+	}
+
+	recorded := []Migration{}
+
+	err := m.withLock(ctx, func(conn *gorm.DB, applied map[int64]schemaMigration) error {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			for _, migration := range m.migrations {
+				if migration.Version > version {
+					break
+				}
+				if _, ok := applied[migration.Version]; ok {
+					continue
+				}
+
+				err := tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name).Error
+				if err != nil {
+					return err
+				}
+				recorded = append(recorded, migration)
+			}
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return recorded, nil
+}
+
+func (m *migratorImpl) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// withLock run fn on a single connection holding the advisory lock, the
+// applied migrations are read once the lock is taken.
+func (m *migratorImpl) withLock(ctx context.Context, fn func(conn *gorm.DB, applied map[int64]schemaMigration) error) error {
+	db := m.db.GetConnection(ctx)
+
+	return db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		err := conn.Exec("SELECT pg_advisory_lock(hashtext(?))", lockName).Error
+		if err != nil {
+			return err
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(hashtext(?))", lockName)
+
+		err = conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version     bigint PRIMARY KEY,
+			name        text NOT NULL,
+			applied_at  timestamptz NOT NULL DEFAULT now()
+		)`).Error
+		if err != nil {
+			return err
+		}
+
+		schemaMigrations := []schemaMigration{}
+		err = conn.
+			Table("schema_migrations").
+			Find(&schemaMigrations).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		applied := map[int64]schemaMigration{}
+		for _, schemaMigration := range schemaMigrations {
+			applied[schemaMigration.Version] = schemaMigration
+		}
+
+		return fn(conn, applied)
+	})
+}
+
+// hasTables report whether the schema has other tables than
+// schema_migrations.
+func hasTables(conn *gorm.DB) (bool, error) {
+	var count int64
+	err := conn.Raw("SELECT count(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name <> 'schema_migrations'").Scan(&count).Error
+	return count > 0, err
+}
+
+func apply(conn *gorm.DB, migration Migration) error {
+	return conn.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(migration.Up).Error
+		if err != nil {
+			return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+		}
+
+		return tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name).Error
+	})
+}
+
+func revert(conn *gorm.DB, migration Migration) error {
+	return conn.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(migration.Down).Error
+		if err != nil {
+			return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+		}
+
+		return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error
+	})
+}
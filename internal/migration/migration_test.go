@@ -0,0 +1,164 @@
+package migration
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	mocks "github.com/zikri124/mygram-api/internal/infrastructure/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		assert.Equal(t, int64(i+1), migration.Version, "migration versions must follow each other")
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+
+	// the legacy databases must still run the migration widening their ids
+	assert.Less(t, LegacySchemaVersion, len(migrations))
+	assert.Equal(t, "widen_ids_to_bigint", migrations[LegacySchemaVersion].Name)
+}
+
+func newMockMigrator(t *testing.T, migrations []Migration) (Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	assert.Nil(t, err)
+
+	postgresMock := mocks.NewGormPostgres(t)
+	postgresMock.On("GetConnection", context.Background()).Return(gormDB)
+
+	return &migratorImpl{db: postgresMock, migrations: migrations}, mock
+}
+
+func expectLock(mock sqlmock.Sqlmock, appliedVersions ...int64) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock(hashtext($1))")).WithArgs(lockName).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	rows := sqlmock.NewRows([]string{"version", "name", "applied_at"})
+	for _, version := range appliedVersions {
+		rows.AddRow(version, "applied", time.Now())
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "schema_migrations"`)).WillReturnRows(rows)
+}
+
+func TestMigratorTo(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "one", Up: "CREATE TABLE one ()", Down: "DROP TABLE one"},
+		{Version: 2, Name: "two", Up: "CREATE TABLE two ()", Down: "DROP TABLE two"},
+		{Version: 3, Name: "three", Up: "CREATE TABLE three ()", Down: "DROP TABLE three"},
+	}
+
+	t.Run("apply the pending migrations in order", func(t *testing.T) {
+		migrator, mock := newMockMigrator(t, migrations)
+		expectLock(mock, 1)
+		for _, migration := range migrations[1:] {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(migration.Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(migration.Version, migration.Name).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}
+		mock.ExpectExec("pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		applied, err := migrator.Up(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, migrations[1:], applied)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("revert the migrations after the version", func(t *testing.T) {
+		migrator, mock := newMockMigrator(t, migrations)
+		expectLock(mock, 1, 2, 3)
+		for _, migration := range []Migration{migrations[2], migrations[1]} {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(migration.Down)).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(migration.Version).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}
+		mock.ExpectExec("pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		reverted, err := migrator.To(context.Background(), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, []Migration{migrations[2], migrations[1]}, reverted)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		migrator := &migratorImpl{migrations: migrations}
+
+		_, err := migrator.To(context.Background(), 4)
+		assert.Equal(t, ErrUnknownVersion, err)
+	})
+}
+
+func TestMigratorExistingDatabase(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "one", Up: "CREATE TABLE one ()", Down: "DROP TABLE one"},
+		{Version: 2, Name: "two", Up: "CREATE TABLE two ()", Down: "DROP TABLE two"},
+	}
+
+	t.Run("up refuse a database with tables and no migration", func(t *testing.T) {
+		migrator, mock := newMockMigrator(t, migrations)
+		expectLock(mock)
+		mock.ExpectQuery("FROM information_schema.tables").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectExec("pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := migrator.Up(context.Background())
+		assert.Equal(t, ErrExistingSchema, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("up on an empty database", func(t *testing.T) {
+		migrator, mock := newMockMigrator(t, migrations[:1])
+		expectLock(mock)
+		mock.ExpectQuery("FROM information_schema.tables").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(migrations[0].Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(int64(1), "one").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectExec("pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		applied, err := migrator.Up(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, migrations[:1], applied)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("baseline record the versions without running them", func(t *testing.T) {
+		migrator, mock := newMockMigrator(t, migrations)
+		expectLock(mock)
+		mock.ExpectBegin()
+		for _, migration := range migrations {
+			mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(migration.Version, migration.Name).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+		mock.ExpectExec("pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		recorded, err := migrator.Baseline(context.Background(), 2)
+		assert.Nil(t, err)
+		assert.Equal(t, migrations, recorded)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("up after the baseline has nothing to apply", func(t *testing.T) {
+		migrator, mock := newMockMigrator(t, migrations)
+		expectLock(mock, 1, 2)
+		mock.ExpectExec("pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		applied, err := migrator.Up(context.Background())
+		assert.Nil(t, err)
+		assert.Empty(t, applied)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,10 @@
+CREATE TABLE users (
+    id          serial PRIMARY KEY,
+    username    text NOT NULL,
+    email       text NOT NULL,
+    password    text NOT NULL,
+    dob         timestamptz NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    updated_at  timestamptz NOT NULL DEFAULT now(),
+    deleted_at  timestamptz
+);
@@ -0,0 +1,10 @@
+CREATE TABLE photos (
+    id          serial PRIMARY KEY,
+    title       text NOT NULL,
+    caption     text NOT NULL DEFAULT '',
+    photo_url   text NOT NULL,
+    user_id     integer NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    updated_at  timestamptz NOT NULL DEFAULT now(),
+    deleted_at  timestamptz
+);
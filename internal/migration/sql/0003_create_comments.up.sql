@@ -0,0 +1,9 @@
+CREATE TABLE comments (
+    id          serial PRIMARY KEY,
+    user_id     integer NOT NULL,
+    photo_id    integer NOT NULL,
+    message     text NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    updated_at  timestamptz NOT NULL DEFAULT now(),
+    deleted_at  timestamptz
+);
@@ -0,0 +1 @@
+DROP TABLE social_medias;
@@ -0,0 +1,9 @@
+CREATE TABLE social_medias (
+    id                serial PRIMARY KEY,
+    user_id           integer NOT NULL,
+    name              text NOT NULL,
+    social_media_url  text NOT NULL,
+    created_at        timestamptz NOT NULL DEFAULT now(),
+    updated_at        timestamptz NOT NULL DEFAULT now(),
+    deleted_at        timestamptz
+);
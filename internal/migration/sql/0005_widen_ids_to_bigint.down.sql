@@ -0,0 +1,2 @@
+-- Intentionally a no-op: narrowing the id columns back to integer would fail
+-- as soon as a snowflake id is stored.
@@ -0,0 +1,20 @@
+-- The first migrations match the schema of the databases created before the
+-- migrations (baselined with `migrate baseline 4`), its 32-bit id columns
+-- cannot hold the snowflake ids. The existing ids keep their values, they are
+-- smaller than every snowflake id so they never collide with the new ones.
+
+ALTER TABLE users
+    ALTER COLUMN id TYPE bigint;
+
+ALTER TABLE photos
+    ALTER COLUMN id TYPE bigint,
+    ALTER COLUMN user_id TYPE bigint;
+
+ALTER TABLE comments
+    ALTER COLUMN id TYPE bigint,
+    ALTER COLUMN user_id TYPE bigint,
+    ALTER COLUMN photo_id TYPE bigint;
+
+ALTER TABLE social_medias
+    ALTER COLUMN id TYPE bigint,
+    ALTER COLUMN user_id TYPE bigint;
@@ -0,0 +1,3 @@
+DROP INDEX users_email_key;
+DROP INDEX users_username_lower_pattern_idx;
+DROP INDEX users_username_lower_key;
@@ -0,0 +1,5 @@
+-- Deleted accounts keep their username and email until they are purged so
+-- they can still be restored.
+CREATE UNIQUE INDEX users_username_lower_key ON users (lower(username));
+CREATE INDEX users_username_lower_pattern_idx ON users (lower(username) text_pattern_ops);
+CREATE UNIQUE INDEX users_email_key ON users (email);
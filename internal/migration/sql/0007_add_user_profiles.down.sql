@@ -0,0 +1,12 @@
+ALTER TABLE users
+    DROP COLUMN privacy_show_social_medias,
+    DROP COLUMN privacy_show_pronouns,
+    DROP COLUMN privacy_show_website,
+    DROP COLUMN privacy_show_bio,
+    DROP COLUMN privacy_show_age,
+    DROP COLUMN pronouns,
+    DROP COLUMN website,
+    DROP COLUMN avatar_url,
+    DROP COLUMN bio,
+    DROP COLUMN display_name,
+    DROP COLUMN role;
@@ -0,0 +1,12 @@
+ALTER TABLE users
+    ADD COLUMN role                       text NOT NULL DEFAULT 'user',
+    ADD COLUMN display_name               text NOT NULL DEFAULT '',
+    ADD COLUMN bio                        text NOT NULL DEFAULT '',
+    ADD COLUMN avatar_url                 text NOT NULL DEFAULT '',
+    ADD COLUMN website                    text NOT NULL DEFAULT '',
+    ADD COLUMN pronouns                   text NOT NULL DEFAULT '',
+    ADD COLUMN privacy_show_age           boolean NOT NULL DEFAULT false,
+    ADD COLUMN privacy_show_bio           boolean NOT NULL DEFAULT true,
+    ADD COLUMN privacy_show_website       boolean NOT NULL DEFAULT true,
+    ADD COLUMN privacy_show_pronouns      boolean NOT NULL DEFAULT true,
+    ADD COLUMN privacy_show_social_medias boolean NOT NULL DEFAULT true;
@@ -0,0 +1,8 @@
+ALTER TABLE photos
+    DROP COLUMN camera_iso,
+    DROP COLUMN camera_focal_length,
+    DROP COLUMN camera_f_number,
+    DROP COLUMN camera_exposure_time,
+    DROP COLUMN camera_lens_model,
+    DROP COLUMN camera_model,
+    DROP COLUMN camera_make;
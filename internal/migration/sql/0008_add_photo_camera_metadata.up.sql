@@ -0,0 +1,8 @@
+ALTER TABLE photos
+    ADD COLUMN camera_make          text NOT NULL DEFAULT '',
+    ADD COLUMN camera_model         text NOT NULL DEFAULT '',
+    ADD COLUMN camera_lens_model    text NOT NULL DEFAULT '',
+    ADD COLUMN camera_exposure_time text NOT NULL DEFAULT '',
+    ADD COLUMN camera_f_number      double precision NOT NULL DEFAULT 0,
+    ADD COLUMN camera_focal_length  double precision NOT NULL DEFAULT 0,
+    ADD COLUMN camera_iso           bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+ALTER TABLE comments
+    ADD COLUMN parent_id bigint REFERENCES comments (id),
+    ADD COLUMN depth     integer NOT NULL DEFAULT 0;
+
+CREATE INDEX comments_parent_id_idx ON comments (parent_id) WHERE parent_id IS NOT NULL;
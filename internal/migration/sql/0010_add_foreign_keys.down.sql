@@ -0,0 +1,8 @@
+ALTER TABLE social_medias
+    DROP CONSTRAINT social_medias_user_id_fkey;
+
+ALTER TABLE comments
+    DROP CONSTRAINT comments_photo_id_fkey;
+
+ALTER TABLE photos
+    DROP CONSTRAINT photos_user_id_fkey;
@@ -0,0 +1,10 @@
+-- comments.user_id has no foreign key, the comments of a purged account that
+-- still have replies are kept with an empty message.
+ALTER TABLE photos
+    ADD CONSTRAINT photos_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id);
+
+ALTER TABLE comments
+    ADD CONSTRAINT comments_photo_id_fkey FOREIGN KEY (photo_id) REFERENCES photos (id);
+
+ALTER TABLE social_medias
+    ADD CONSTRAINT social_medias_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id);
@@ -0,0 +1,10 @@
+DROP INDEX social_medias_user_id_deleted_at_idx;
+DROP INDEX comments_user_id_deleted_at_idx;
+DROP INDEX photos_user_id_deleted_at_idx;
+DROP INDEX users_deleted_at_idx;
+
+DROP INDEX social_medias_user_id_idx;
+DROP INDEX comments_user_id_idx;
+DROP INDEX comments_photo_id_created_at_idx;
+DROP INDEX photos_created_at_idx;
+DROP INDEX photos_user_id_created_at_idx;
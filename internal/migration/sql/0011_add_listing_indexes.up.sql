@@ -0,0 +1,11 @@
+CREATE INDEX photos_user_id_created_at_idx ON photos (user_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX photos_created_at_idx ON photos (created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX comments_photo_id_created_at_idx ON comments (photo_id, created_at, id);
+CREATE INDEX comments_user_id_idx ON comments (user_id);
+CREATE INDEX social_medias_user_id_idx ON social_medias (user_id) WHERE deleted_at IS NULL;
+
+-- the trash listing and the purge worker only look at the deleted rows
+CREATE INDEX users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX photos_user_id_deleted_at_idx ON photos (user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX comments_user_id_deleted_at_idx ON comments (user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX social_medias_user_id_deleted_at_idx ON social_medias (user_id, deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE photo_tags;
+DROP TABLE tags;
@@ -0,0 +1,15 @@
+CREATE TABLE tags (
+    id          bigint PRIMARY KEY,
+    name        text NOT NULL UNIQUE,
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE photo_tags (
+    photo_id    bigint NOT NULL REFERENCES photos (id) ON DELETE CASCADE,
+    tag_id      bigint NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (photo_id, tag_id)
+);
+
+CREATE INDEX photo_tags_tag_id_idx ON photo_tags (tag_id);
+CREATE INDEX photo_tags_created_at_idx ON photo_tags (created_at);
@@ -0,0 +1,12 @@
+CREATE TABLE mentions (
+    id           bigint PRIMARY KEY,
+    source_type  text NOT NULL,
+    source_id    bigint NOT NULL,
+    user_id      bigint NOT NULL,
+    text_offset  integer NOT NULL,
+    text_length  integer NOT NULL,
+    created_at   timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX mentions_source_idx ON mentions (source_type, source_id);
+CREATE INDEX mentions_user_id_idx ON mentions (user_id);
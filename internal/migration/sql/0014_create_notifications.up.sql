@@ -0,0 +1,14 @@
+CREATE TABLE notifications (
+    id           bigint PRIMARY KEY,
+    user_id      bigint NOT NULL,
+    actor_id     bigint NOT NULL,
+    type         text NOT NULL,
+    entity_type  text NOT NULL,
+    entity_id    bigint NOT NULL,
+    read_at      timestamptz,
+    created_at   timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX notifications_user_id_created_at_idx ON notifications (user_id, created_at DESC, id DESC);
+CREATE INDEX notifications_user_id_unread_idx ON notifications (user_id) WHERE read_at IS NULL;
+CREATE INDEX notifications_actor_id_idx ON notifications (actor_id);
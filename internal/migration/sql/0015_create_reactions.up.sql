@@ -0,0 +1,11 @@
+CREATE TABLE reactions (
+    target_type  text NOT NULL,
+    target_id    bigint NOT NULL,
+    user_id      bigint NOT NULL,
+    emoji        text NOT NULL,
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    updated_at   timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (target_type, target_id, user_id)
+);
+
+CREATE INDEX reactions_user_id_idx ON reactions (user_id);
@@ -0,0 +1,32 @@
+CREATE TABLE webhooks (
+    id          bigint PRIMARY KEY,
+    user_id     bigint NOT NULL REFERENCES users (id),
+    url         text NOT NULL,
+    secret      text NOT NULL,
+    events      text NOT NULL DEFAULT '[]',
+    active      boolean NOT NULL DEFAULT true,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    updated_at  timestamptz NOT NULL DEFAULT now(),
+    deleted_at  timestamptz
+);
+
+CREATE INDEX webhooks_user_id_idx ON webhooks (user_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE webhook_deliveries (
+    id               bigint PRIMARY KEY,
+    webhook_id       bigint NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event            text NOT NULL,
+    payload          text NOT NULL,
+    status           text NOT NULL,
+    attempts         integer NOT NULL DEFAULT 0,
+    response_code    integer,
+    response_body    text NOT NULL DEFAULT '',
+    error            text NOT NULL DEFAULT '',
+    next_attempt_at  timestamptz,
+    delivered_at     timestamptz,
+    created_at       timestamptz NOT NULL DEFAULT now(),
+    updated_at       timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, created_at DESC);
+CREATE INDEX webhook_deliveries_status_next_attempt_at_idx ON webhook_deliveries (status, next_attempt_at);
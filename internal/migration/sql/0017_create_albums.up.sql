@@ -0,0 +1,22 @@
+CREATE TABLE albums (
+    id              bigint PRIMARY KEY,
+    user_id         bigint NOT NULL REFERENCES users (id),
+    title           text NOT NULL,
+    description     text NOT NULL DEFAULT '',
+    cover_photo_id  bigint REFERENCES photos (id) ON DELETE SET NULL,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+    deleted_at      timestamptz
+);
+
+CREATE INDEX albums_user_id_idx ON albums (user_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE album_photos (
+    album_id    bigint NOT NULL REFERENCES albums (id) ON DELETE CASCADE,
+    photo_id    bigint NOT NULL REFERENCES photos (id) ON DELETE CASCADE,
+    position    integer NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (album_id, photo_id)
+);
+
+CREATE INDEX album_photos_photo_id_idx ON album_photos (photo_id);
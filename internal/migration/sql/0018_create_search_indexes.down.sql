@@ -0,0 +1,3 @@
+DROP INDEX comments_search_idx;
+DROP INDEX photos_search_idx;
+DROP INDEX users_search_idx;
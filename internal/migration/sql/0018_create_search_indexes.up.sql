@@ -0,0 +1,5 @@
+-- The expressions must stay identical to the search vectors of
+-- internal/repository/search_repository.go or postgres will not use them.
+CREATE INDEX users_search_idx ON users USING gin (to_tsvector('simple', users.username));
+CREATE INDEX photos_search_idx ON photos USING gin ((setweight(to_tsvector('simple', photos.title), 'A') || setweight(to_tsvector('simple', photos.caption), 'B')));
+CREATE INDEX comments_search_idx ON comments USING gin (to_tsvector('simple', comments.message));
@@ -0,0 +1,17 @@
+CREATE TABLE data_exports (
+    id              bigint PRIMARY KEY,
+    user_id         bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status          text NOT NULL,
+    attempts        integer NOT NULL DEFAULT 0,
+    error           text NOT NULL DEFAULT '',
+    file_name       text NOT NULL DEFAULT '',
+    download_token  text NOT NULL DEFAULT '',
+    claimed_until   timestamptz,
+    expires_at      timestamptz,
+    completed_at    timestamptz,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX data_exports_status_claimed_until_idx ON data_exports (status, claimed_until);
+CREATE INDEX data_exports_user_id_status_idx ON data_exports (user_id, status);
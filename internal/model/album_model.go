@@ -0,0 +1,96 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
+	"gorm.io/gorm"
+)
+
+var AlbumSorts = []string{pagination.SortNewest, pagination.SortOldest}
+
+type Album struct {
+	ID           publicid.ID  `json:"id"`
+	UserId       publicid.ID  `json:"user_id"`
+	Title        string       `json:"title"`
+	Description  string       `json:"description"`
+	CoverPhotoId *publicid.ID `json:"cover_photo_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt
+}
+
+type AlbumPhoto struct {
+	AlbumId   publicid.ID `json:"album_id"`
+	PhotoId   publicid.ID `json:"photo_id"`
+	Position  int         `json:"position"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type CreateAlbum struct {
+	Title        string       `json:"title" validate:"required"`
+	Description  string       `json:"description"`
+	CoverPhotoId *publicid.ID `json:"cover_photo_id"`
+}
+
+type UpdateAlbum struct {
+	Title        string       `json:"title" validate:"required"`
+	Description  string       `json:"description"`
+	CoverPhotoId *publicid.ID `json:"cover_photo_id"`
+}
+
+type AddAlbumPhoto struct {
+	PhotoId publicid.ID `json:"photo_id" validate:"required"`
+}
+
+type ReorderAlbumPhotos struct {
+	PhotoIds []publicid.ID `json:"photo_ids" validate:"required,min=1"`
+}
+
+type AlbumRes struct {
+	ID           publicid.ID  `json:"id"`
+	UserId       publicid.ID  `json:"user_id"`
+	Title        string       `json:"title"`
+	Description  string       `json:"description"`
+	CoverPhotoId *publicid.ID `json:"cover_photo_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+type AlbumView struct {
+	ID           publicid.ID  `json:"id"`
+	UserId       publicid.ID  `json:"user_id"`
+	Title        string       `json:"title"`
+	Description  string       `json:"description"`
+	CoverPhotoId *publicid.ID `json:"cover_photo_id"`
+	PhotoCount   int64        `json:"photo_count"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	User         UserItem     `json:"user" gorm:"foreignKey:UserId;references:ID"`
+	CoverPhoto   *PhotoItem   `json:"cover_photo" gorm:"foreignKey:CoverPhotoId;references:ID"`
+}
+
+type AlbumPhotoView struct {
+	AlbumId  publicid.ID `json:"-"`
+	PhotoId  publicid.ID `json:"-"`
+	Position int         `json:"position"`
+	Photo    PhotoItem   `json:"photo" gorm:"foreignKey:PhotoId;references:ID"`
+}
+
+type AlbumDetail struct {
+	AlbumView
+	Photos []AlbumPhotoView `json:"photos"`
+}
+
+func (a AlbumView) SortKey(sort string) (int64, publicid.ID) {
+	return pagination.TimeValue(a.CreatedAt), a.ID
+}
+
+func (a *Album) BeforeCreate(db *gorm.DB) (err error) {
+	if a.ID == 0 {
+		a.ID = publicid.ID(snowflake.NextID())
+	}
+	return
+}
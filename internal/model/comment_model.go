@@ -3,31 +3,45 @@ package model
 import (
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
 	"gorm.io/gorm"
 )
 
+// DeletedCommentMessage replace the message of a deleted comment still shown
+// because it has replies.
+const DeletedCommentMessage = "[deleted]"
+
+var CommentSorts = []string{pagination.SortOldest, pagination.SortNewest, pagination.SortMostLiked, pagination.SortMostCommented}
+
 type Comment struct {
-	ID        uint32    `json:"id"`
-	UserId    uint32    `json:"user_id"`
-	PhotoId   uint32    `json:"photo_id"`
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        publicid.ID  `json:"id"`
+	UserId    publicid.ID  `json:"user_id"`
+	PhotoId   publicid.ID  `json:"photo_id"`
+	ParentId  *publicid.ID `json:"parent_id"`
+	Depth     int          `json:"depth"`
+	Message   string       `json:"message"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
 	DeletedAt gorm.DeletedAt
 }
 
 type CreateComment struct {
-	PhotoId uint32 `json:"photo_id" validate:"required"`
-	Message string `json:"message" validate:"required"`
+	PhotoId  publicid.ID  `json:"photo_id" validate:"required"`
+	ParentId *publicid.ID `json:"parent_id"`
+	Message  string       `json:"message" validate:"required"`
 }
 
 type CreateCommentRes struct {
-	ID        uint32    `json:"id"`
-	UserId    uint32    `json:"user_id"`
-	Message   string    `json:"message"`
-	PhotoId   uint32    `json:"photo_id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        publicid.ID   `json:"id"`
+	UserId    publicid.ID   `json:"user_id"`
+	Message   string        `json:"message"`
+	PhotoId   publicid.ID   `json:"photo_id"`
+	ParentId  *publicid.ID  `json:"parent_id"`
+	Depth     int           `json:"depth"`
+	Mentions  []MentionView `json:"mentions"`
+	CreatedAt time.Time     `json:"created_at"`
 }
 
 type UpdateComment struct {
@@ -35,27 +49,55 @@ type UpdateComment struct {
 }
 
 type UpdateCommentRes struct {
-	ID        uint32    `json:"id"`
-	UserId    uint32    `json:"user_id"`
-	Message   string    `json:"message"`
-	PhotoId   uint32    `json:"photo_id"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        publicid.ID   `json:"id"`
+	UserId    publicid.ID   `json:"user_id"`
+	Message   string        `json:"message"`
+	PhotoId   publicid.ID   `json:"photo_id"`
+	Mentions  []MentionView `json:"mentions"`
+	UpdatedAt time.Time     `json:"updated_at"`
 }
 
 type CommentView struct {
-	ID        uint32    `json:"id"`
-	UserId    uint32    `json:"user_id"`
-	PhotoId   uint32    `json:"photo_id"`
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	User      UserItem  `json:"user" gorm:"foreignKey:UserId;references:ID"`
-	Photo     PhotoItem `json:"photo" gorm:"foreignKey:PhotoId;references:ID"`
+	ID         publicid.ID     `json:"id"`
+	UserId     publicid.ID     `json:"user_id"`
+	PhotoId    publicid.ID     `json:"photo_id"`
+	ParentId   *publicid.ID    `json:"parent_id"`
+	Depth      int             `json:"depth"`
+	Message    string          `json:"message"`
+	ReplyCount int64           `json:"reply_count"`
+	LikeCount  int64           `json:"like_count"`
+	Reactions  []ReactionCount `json:"reactions" gorm:"-"`
+	MyReaction *string         `json:"my_reaction" gorm:"-"`
+	IsDeleted  bool            `json:"is_deleted" gorm:"-"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  *time.Time      `json:"-"`
+	User       UserItem        `json:"user" gorm:"foreignKey:UserId;references:ID"`
+	Photo      PhotoItem       `json:"photo" gorm:"foreignKey:PhotoId;references:ID"`
+	Mentions   []MentionView   `json:"mentions" gorm:"polymorphicType:SourceType;polymorphicId:SourceId;polymorphicValue:comment"`
+}
+
+type CommentRepliesRes struct {
+	ParentId   publicid.ID `json:"parent_id"`
+	ReplyCount int64       `json:"reply_count"`
+	pagination.Page
+}
+
+// SortKey return the cursor value of the comment for the given sort, the
+// replies count for most_commented.
+func (c CommentView) SortKey(sort string) (int64, publicid.ID) {
+	switch sort {
+	case pagination.SortMostLiked:
+		return c.LikeCount, c.ID
+	case pagination.SortMostCommented:
+		return c.ReplyCount, c.ID
+	}
+	return pagination.TimeValue(c.CreatedAt), c.ID
 }
 
 func (c *Comment) BeforeCreate(db *gorm.DB) (err error) {
 	if c.ID == 0 {
-		c.ID = uuid.New().ID()
+		c.ID = publicid.ID(snowflake.NextID())
 	}
 	return
 }
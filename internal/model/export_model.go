@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
+	"gorm.io/gorm"
+)
+
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+	DataExportExpired = "expired"
+)
+
+// DataExport is a job building the ZIP archive of the personal data of a
+// user, the archive can be downloaded with the token until ExpiresAt.
+type DataExport struct {
+	ID            publicid.ID `json:"id"`
+	UserId        publicid.ID `json:"user_id"`
+	Status        string      `json:"status"`
+	Attempts      int         `json:"attempts"`
+	Error         string      `json:"error"`
+	FileName      string      `json:"-"`
+	DownloadToken string      `json:"-"`
+	ClaimedUntil  *time.Time  `json:"-"`
+	ExpiresAt     *time.Time  `json:"expires_at"`
+	CompletedAt   *time.Time  `json:"completed_at"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+type DataExportRes struct {
+	ID          publicid.ID `json:"id"`
+	Status      string      `json:"status"`
+	Error       string      `json:"error"`
+	DownloadUrl string      `json:"download_url,omitempty"`
+	ExpiresAt   *time.Time  `json:"expires_at"`
+	CompletedAt *time.Time  `json:"completed_at"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// ExportFollows is the follows.json file of the archive.
+type ExportFollows struct {
+	Followers []Follow `json:"followers"`
+	Following []Follow `json:"following"`
+}
+
+func (d *DataExport) BeforeCreate(db *gorm.DB) (err error) {
+	if d.ID == 0 {
+		d.ID = publicid.ID(snowflake.NextID())
+	}
+	return
+}
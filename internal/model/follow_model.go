@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+// Follow of FolloweeId by FollowerId, a user follows another user at most
+// once.
+type Follow struct {
+	FollowerId publicid.ID `json:"follower_id" gorm:"primaryKey"`
+	FolloweeId publicid.ID `json:"followee_id" gorm:"primaryKey"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
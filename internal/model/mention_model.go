@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
+	"gorm.io/gorm"
+)
+
+const (
+	MentionSourcePhoto   = "photo"
+	MentionSourceComment = "comment"
+)
+
+type Mention struct {
+	ID         publicid.ID `json:"id"`
+	SourceType string      `json:"source_type"`
+	SourceId   publicid.ID `json:"source_id"`
+	UserId     publicid.ID `json:"user_id"`
+	Offset     int         `json:"offset" gorm:"column:text_offset"`
+	Length     int         `json:"length" gorm:"column:text_length"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type MentionView struct {
+	SourceType string      `json:"-"`
+	SourceId   publicid.ID `json:"-"`
+	UserId     publicid.ID `json:"-"`
+	Offset     int         `json:"offset" gorm:"column:text_offset"`
+	Length     int         `json:"length" gorm:"column:text_length"`
+	User       UserItem    `json:"user" gorm:"foreignKey:UserId;references:ID"`
+}
+
+func (m *Mention) BeforeCreate(db *gorm.DB) (err error) {
+	if m.ID == 0 {
+		m.ID = publicid.ID(snowflake.NextID())
+	}
+	return
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
+	"gorm.io/gorm"
+)
+
+const (
+	NotificationTypeComment = "comment"
+	NotificationTypeReply   = "reply"
+	NotificationTypeLike    = "like"
+	NotificationTypeFollow  = "follow"
+	NotificationTypeMention = "mention"
+)
+
+type Notification struct {
+	ID         publicid.ID `json:"id"`
+	UserId     publicid.ID `json:"user_id"`
+	ActorId    publicid.ID `json:"actor_id"`
+	Type       string      `json:"type"`
+	EntityType string      `json:"entity_type"`
+	EntityId   publicid.ID `json:"entity_id"`
+	ReadAt     *time.Time  `json:"read_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type NotificationView struct {
+	ID         publicid.ID `json:"id"`
+	UserId     publicid.ID `json:"user_id"`
+	ActorId    publicid.ID `json:"actor_id"`
+	Type       string      `json:"type"`
+	EntityType string      `json:"entity_type"`
+	EntityId   publicid.ID `json:"entity_id"`
+	ReadAt     *time.Time  `json:"read_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+	Actor      UserItem    `json:"actor" gorm:"foreignKey:ActorId;references:ID"`
+}
+
+type NotificationGroup struct {
+	Type            string        `json:"type"`
+	EntityType      string        `json:"entity_type"`
+	EntityId        publicid.ID   `json:"entity_id"`
+	Message         string        `json:"message"`
+	Actors          []UserItem    `json:"actors"`
+	ActorCount      int           `json:"actor_count"`
+	NotificationIds []publicid.ID `json:"notification_ids"`
+	IsRead          bool          `json:"is_read"`
+	LatestAt        time.Time     `json:"latest_at"`
+}
+
+type NotificationList struct {
+	Notifications []NotificationGroup `json:"notifications"`
+	UnreadCount   int64               `json:"unread_count"`
+	NextCursor    string              `json:"next_cursor"`
+}
+
+type MarkNotificationsRead struct {
+	NotificationIds []publicid.ID `json:"notification_ids" validate:"required,min=1"`
+}
+
+func (n NotificationView) SortKey(sort string) (int64, publicid.ID) {
+	return pagination.TimeValue(n.CreatedAt), n.ID
+}
+
+func (n *Notification) BeforeCreate(db *gorm.DB) (err error) {
+	if n.ID == 0 {
+		n.ID = publicid.ID(snowflake.NextID())
+	}
+	return
+}
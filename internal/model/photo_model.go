@@ -3,60 +3,88 @@ package model
 import (
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
 	"gorm.io/gorm"
 )
 
+var PhotoSorts = []string{pagination.SortNewest, pagination.SortOldest, pagination.SortMostLiked, pagination.SortMostCommented}
+
 type Photo struct {
-	ID        uint32    `json:"id"`
-	Title     string    `json:"title"`
-	Caption   string    `json:"caption"`
-	PhotoUrl  string    `json:"photo_url"`
-	UserId    uint32    `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        publicid.ID `json:"id"`
+	Title     string      `json:"title"`
+	Caption   string      `json:"caption"`
+	PhotoUrl  string      `json:"photo_url"`
+	UserId    publicid.ID `json:"user_id"`
+	Camera    PhotoCamera `json:"camera" gorm:"embedded;embeddedPrefix:camera_"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
 	DeletedAt gorm.DeletedAt
 }
 
+type PhotoCamera struct {
+	Make         string  `json:"make,omitempty"`
+	Model        string  `json:"model,omitempty"`
+	LensModel    string  `json:"lens_model,omitempty"`
+	ExposureTime string  `json:"exposure_time,omitempty"`
+	FNumber      float64 `json:"f_number,omitempty"`
+	FocalLength  float64 `json:"focal_length,omitempty"`
+	ISO          uint32  `json:"iso,omitempty"`
+}
+
 type PhotoCreate struct {
 	Title    string `json:"title" validate:"required"`
 	Caption  string `json:"caption"`
 	PhotoUrl string `json:"photo_url" validate:"required"`
 }
 
+type PhotoUpload struct {
+	Title          string `form:"title" validate:"required"`
+	Caption        string `form:"caption"`
+	KeepCameraInfo bool   `form:"keep_camera_info"`
+}
+
 type PhotoView struct {
-	ID        uint32    `json:"id"`
-	Title     string    `json:"title"`
-	Caption   string    `json:"caption"`
-	PhotoUrl  string    `json:"photo_url"`
-	UserId    uint32    `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
-	User      UserItem  `json:"user" gorm:"foreignKey:UserId;references:ID"`
+	ID           publicid.ID   `json:"id"`
+	Title        string        `json:"title"`
+	Caption      string        `json:"caption"`
+	PhotoUrl     string        `json:"photo_url"`
+	UserId       publicid.ID   `json:"user_id"`
+	Camera       PhotoCamera   `json:"camera" gorm:"embedded;embeddedPrefix:camera_"`
+	LikeCount    int64         `json:"like_count"`
+	CommentCount int64         `json:"comment_count"`
+	CreatedAt    time.Time     `json:"created_at"`
+	User         UserItem      `json:"user" gorm:"foreignKey:UserId;references:ID"`
+	Mentions     []MentionView `json:"mentions" gorm:"polymorphicType:SourceType;polymorphicId:SourceId;polymorphicValue:photo"`
 }
 
 type PhotoResCreate struct {
-	ID        uint32    `json:"id"`
-	Title     string    `json:"title"`
-	Caption   string    `json:"caption"`
-	PhotoUrl  string    `json:"photo_url"`
-	UserId    uint32    `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        publicid.ID   `json:"id"`
+	Title     string        `json:"title"`
+	Caption   string        `json:"caption"`
+	PhotoUrl  string        `json:"photo_url"`
+	UserId    publicid.ID   `json:"user_id"`
+	Camera    PhotoCamera   `json:"camera"`
+	Mentions  []MentionView `json:"mentions"`
+	CreatedAt time.Time     `json:"created_at"`
 }
 
 type PhotoResUpdate struct {
-	ID        uint32    `json:"id"`
-	Title     string    `json:"title"`
-	Caption   string    `json:"caption"`
-	PhotoUrl  string    `json:"photo_url"`
-	UserId    uint32    `json:"user_id"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        publicid.ID   `json:"id"`
+	Title     string        `json:"title"`
+	Caption   string        `json:"caption"`
+	PhotoUrl  string        `json:"photo_url"`
+	UserId    publicid.ID   `json:"user_id"`
+	Mentions  []MentionView `json:"mentions"`
+	UpdatedAt time.Time     `json:"updated_at"`
 }
 
 type CreatePhoto struct {
-	Title    string `json:"title" validate:"required"`
-	Caption  string `json:"caption"`
-	PhotoUrl string `json:"photo_url" validate:"required"`
-	UserId   uint32 `json:"user_id" validate:"required"`
+	Title    string      `json:"title" validate:"required"`
+	Caption  string      `json:"caption"`
+	PhotoUrl string      `json:"photo_url" validate:"required"`
+	UserId   publicid.ID `json:"user_id" validate:"required"`
 }
 
 type UpdatePhoto struct {
@@ -66,16 +94,27 @@ type UpdatePhoto struct {
 }
 
 type PhotoItem struct {
-	ID       uint32 `json:"id"`
-	Title    string `json:"title"`
-	Caption  string `json:"caption"`
-	PhotoUrl string `json:"photo_url"`
-	UserId   uint32 `json:"user_id"`
+	ID       publicid.ID `json:"id"`
+	Title    string      `json:"title"`
+	Caption  string      `json:"caption"`
+	PhotoUrl string      `json:"photo_url"`
+	UserId   publicid.ID `json:"user_id"`
+}
+
+// SortKey return the cursor value of the photo for the given sort.
+func (p PhotoView) SortKey(sort string) (int64, publicid.ID) {
+	switch sort {
+	case pagination.SortMostLiked:
+		return p.LikeCount, p.ID
+	case pagination.SortMostCommented:
+		return p.CommentCount, p.ID
+	}
+	return pagination.TimeValue(p.CreatedAt), p.ID
 }
 
 func (p *Photo) BeforeCreate(db *gorm.DB) (err error) {
 	if p.ID == 0 {
-		p.ID = uuid.New().ID()
+		p.ID = publicid.ID(snowflake.NextID())
 	}
 	return
 }
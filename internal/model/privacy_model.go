@@ -0,0 +1,46 @@
+package model
+
+import (
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+// PrivacySettings tell which profile fields are shown to the other users, the
+// user and the admins always see every field.
+type PrivacySettings struct {
+	ShowAge          bool `json:"show_age"`
+	ShowBio          bool `json:"show_bio"`
+	ShowWebsite      bool `json:"show_website"`
+	ShowPronouns     bool `json:"show_pronouns"`
+	ShowSocialMedias bool `json:"show_social_medias"`
+}
+
+// DefaultPrivacySettings of the new users, the age is derived from the date of
+// birth so it is private until the user choose otherwise.
+var DefaultPrivacySettings = PrivacySettings{
+	ShowAge:          false,
+	ShowBio:          true,
+	ShowWebsite:      true,
+	ShowPronouns:     true,
+	ShowSocialMedias: true,
+}
+
+// UpdatePrivacySettings only change the settings present in the body.
+type UpdatePrivacySettings struct {
+	ShowAge          *bool `json:"show_age"`
+	ShowBio          *bool `json:"show_bio"`
+	ShowWebsite      *bool `json:"show_website"`
+	ShowPronouns     *bool `json:"show_pronouns"`
+	ShowSocialMedias *bool `json:"show_social_medias"`
+}
+
+// Viewer is the user reading another user's data, the ID is 0 for an
+// anonymous viewer.
+type Viewer struct {
+	ID   publicid.ID
+	Role string
+}
+
+// CanSeePrivate tell whether the viewer see the private fields of userId.
+func (v Viewer) CanSeePrivate(userId publicid.ID) bool {
+	return (v.ID != 0 && v.ID == userId) || v.Role == UserRoleAdmin
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+const (
+	ReactionTargetComment = "comment"
+	ReactionTargetPhoto   = "photo"
+)
+
+// ReactionEmojis is the fixed set of reactions a user can choose from.
+var ReactionEmojis = []string{"👍", "❤️", "😂", "😮", "😢", "🔥"}
+
+// Reaction of a user on a comment or a photo, a user has at most one
+// reaction per target.
+type Reaction struct {
+	TargetType string      `json:"target_type" gorm:"primaryKey"`
+	TargetId   publicid.ID `json:"target_id" gorm:"primaryKey"`
+	UserId     publicid.ID `json:"user_id" gorm:"primaryKey"`
+	Emoji      string      `json:"emoji"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+type ReactionCount struct {
+	Emoji string `json:"emoji"`
+	Count int64  `json:"count"`
+}
+
+type TargetReactionCount struct {
+	TargetId publicid.ID
+	Emoji    string
+	Count    int64
+}
+
+type ReactionSummary struct {
+	TargetType string          `json:"target_type"`
+	TargetId   publicid.ID     `json:"target_id"`
+	Reactions  []ReactionCount `json:"reactions"`
+	MyReaction *string         `json:"my_reaction"`
+}
+
+// ReactionTarget describe the reacted comment or photo, the owner is notified
+// of new reactions and PhotoId is the photo stream the change is pushed to.
+type ReactionTarget struct {
+	Type    string
+	Id      publicid.ID
+	OwnerId publicid.ID
+	PhotoId publicid.ID
+}
+
+type SetReaction struct {
+	Emoji string `json:"emoji" validate:"required"`
+}
+
+func IsValidReactionEmoji(emoji string) bool {
+	for _, reactionEmoji := range ReactionEmojis {
+		if reactionEmoji == emoji {
+			return true
+		}
+	}
+	return false
+}
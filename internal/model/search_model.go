@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+const (
+	SearchTypeUser    = "user"
+	SearchTypePhoto   = "photo"
+	SearchTypeComment = "comment"
+)
+
+var SearchTypes = []string{SearchTypeUser, SearchTypePhoto, SearchTypeComment}
+
+type UserSearchItem struct {
+	ID       publicid.ID `json:"id"`
+	Username string      `json:"username"`
+	Rank     float64     `json:"rank"`
+}
+
+type PhotoSearchItem struct {
+	ID        publicid.ID `json:"id"`
+	Title     string      `json:"title"`
+	Caption   string      `json:"caption"`
+	PhotoUrl  string      `json:"photo_url"`
+	UserId    publicid.ID `json:"user_id"`
+	Rank      float64     `json:"rank"`
+	CreatedAt time.Time   `json:"created_at"`
+	User      UserItem    `json:"user" gorm:"foreignKey:UserId;references:ID"`
+}
+
+type CommentSearchItem struct {
+	ID        publicid.ID `json:"id"`
+	PhotoId   publicid.ID `json:"photo_id"`
+	UserId    publicid.ID `json:"user_id"`
+	Message   string      `json:"message"`
+	Rank      float64     `json:"rank"`
+	CreatedAt time.Time   `json:"created_at"`
+	User      UserItem    `json:"user" gorm:"foreignKey:UserId;references:ID"`
+}
+
+type SearchRes struct {
+	Query    string              `json:"query"`
+	Users    []UserSearchItem    `json:"users"`
+	Photos   []PhotoSearchItem   `json:"photos"`
+	Comments []CommentSearchItem `json:"comments"`
+}
+
+func IsValidSearchType(searchType string) bool {
+	for _, valid := range SearchTypes {
+		if searchType == valid {
+			return true
+		}
+	}
+	return false
+}
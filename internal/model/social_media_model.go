@@ -3,17 +3,21 @@ package model
 import (
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
 	"gorm.io/gorm"
 )
 
+var SocialMediaSorts = []string{pagination.SortNewest, pagination.SortOldest}
+
 type SocialMedia struct {
-	ID             uint32    `json:"id"`
-	UserId         uint32    `json:"user_id"`
-	Name           string    `json:"name"`
-	SocialMediaUrl string    `json:"social_media_url"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             publicid.ID `json:"id"`
+	UserId         publicid.ID `json:"user_id"`
+	Name           string      `json:"name"`
+	SocialMediaUrl string      `json:"social_media_url"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt
 }
 
@@ -23,34 +27,38 @@ type NewSocialMedia struct {
 }
 
 type CreateSocialMediaRes struct {
-	ID             uint32    `json:"id"`
-	UserId         uint32    `json:"user_id"`
-	Name           string    `json:"name"`
-	SocialMediaUrl string    `json:"social_media_url"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             publicid.ID `json:"id"`
+	UserId         publicid.ID `json:"user_id"`
+	Name           string      `json:"name"`
+	SocialMediaUrl string      `json:"social_media_url"`
+	CreatedAt      time.Time   `json:"created_at"`
 }
 
 type UpdateSocialMediaRes struct {
-	ID             uint32    `json:"id"`
-	UserId         uint32    `json:"user_id"`
-	Name           string    `json:"name"`
-	SocialMediaUrl string    `json:"social_media_url"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             publicid.ID `json:"id"`
+	UserId         publicid.ID `json:"user_id"`
+	Name           string      `json:"name"`
+	SocialMediaUrl string      `json:"social_media_url"`
+	UpdatedAt      time.Time   `json:"updated_at"`
 }
 
 type SocialMediaView struct {
-	ID             uint32    `json:"id"`
-	UserId         uint32    `json:"user_id"`
-	Name           string    `json:"name"`
-	SocialMediaUrl string    `json:"social_media_url"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	User           UserItem  `json:"user" gorm:"foreignKey:UserId;references:ID"`
+	ID             publicid.ID `json:"id"`
+	UserId         publicid.ID `json:"user_id"`
+	Name           string      `json:"name"`
+	SocialMediaUrl string      `json:"social_media_url"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	User           UserItem    `json:"user" gorm:"foreignKey:UserId;references:ID"`
+}
+
+func (s SocialMediaView) SortKey(sort string) (int64, publicid.ID) {
+	return pagination.TimeValue(s.CreatedAt), s.ID
 }
 
 func (u *SocialMedia) BeforeCreate(db *gorm.DB) (err error) {
 	if u.ID == 0 {
-		u.ID = uuid.New().ID()
+		u.ID = publicid.ID(snowflake.NextID())
 	}
 	return
 }
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
+	"gorm.io/gorm"
+)
+
+type Tag struct {
+	ID        publicid.ID `json:"id"`
+	Name      string      `json:"name"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type PhotoTag struct {
+	PhotoId   publicid.ID `json:"photo_id"`
+	TagId     publicid.ID `json:"tag_id"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type TagView struct {
+	Name       string `json:"name"`
+	PhotoCount int64  `json:"photo_count"`
+}
+
+type TagPhotosRes struct {
+	Tag        string `json:"tag"`
+	PhotoCount int64  `json:"photo_count"`
+	pagination.Page
+}
+
+func (t *Tag) BeforeCreate(db *gorm.DB) (err error) {
+	if t.ID == 0 {
+		t.ID = publicid.ID(snowflake.NextID())
+	}
+	return
+}
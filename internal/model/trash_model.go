@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+const (
+	TrashTypePhoto       = "photo"
+	TrashTypeComment     = "comment"
+	TrashTypeSocialMedia = "social_media"
+)
+
+// TrashItem is a photo, comment or social media deleted by the user, it can
+// be restored until PurgeAt.
+type TrashItem struct {
+	Type      string      `json:"type"`
+	ID        publicid.ID `json:"id"`
+	Preview   string      `json:"preview"`
+	DeletedAt time.Time   `json:"deleted_at"`
+	PurgeAt   time.Time   `json:"purge_at" gorm:"-"`
+}
+
+// DeletedItem is the owner and the deletion time of a trash item.
+type DeletedItem struct {
+	ID        publicid.ID
+	UserId    publicid.ID
+	PhotoId   publicid.ID
+	DeletedAt time.Time
+}
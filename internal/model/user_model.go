@@ -1,21 +1,42 @@
 package model
 
 import (
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
 	"gorm.io/gorm"
 )
 
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin"
+)
+
+// ReservedUsernames cannot be registered, they are route names or could be
+// mistaken for the staff.
+var ReservedUsernames = []string{
+	"admin", "administrator", "api", "autocomplete", "by-username", "help", "login", "me",
+	"mod", "moderator", "mygram", "register", "root", "settings", "staff", "support", "system",
+}
+
 type User struct {
-	ID        uint32    `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Password  string    `json:"password"`
-	DOB       time.Time `json:"dob"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	DeletedAt gorm.DeletedAt
+	ID          publicid.ID     `json:"id"`
+	Username    string          `json:"username"`
+	Email       string          `json:"email"`
+	Password    string          `json:"-"`
+	DOB         time.Time       `json:"dob"`
+	Role        string          `json:"role"`
+	DisplayName string          `json:"display_name"`
+	Bio         string          `json:"bio"`
+	AvatarUrl   string          `json:"avatar_url"`
+	Website     string          `json:"website"`
+	Pronouns    string          `json:"pronouns"`
+	Privacy     PrivacySettings `json:"privacy" gorm:"embedded;embeddedPrefix:privacy_"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt
 }
 
 type UserSignUp struct {
@@ -35,22 +56,74 @@ type UserEdit struct {
 	Username string `json:"username" validate:"required"`
 }
 
+// UserView is the view of the user by themself.
 type UserView struct {
-	ID       uint32 `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Age      uint16 `json:"age"`
+	ID       publicid.ID `json:"id"`
+	Username string      `json:"username"`
+	Email    string      `json:"email"`
+	Age      uint16      `json:"age"`
+	Role     string      `json:"role"`
+}
+
+// UserPublicView is the view of the user by anyone, the email is only set for
+// the user and the admins and the age only when the user made it public.
+type UserPublicView struct {
+	ID          publicid.ID `json:"id"`
+	Username    string      `json:"username"`
+	DisplayName string      `json:"display_name"`
+	AvatarUrl   string      `json:"avatar_url"`
+	Email       string      `json:"email,omitempty"`
+	Age         *uint16     `json:"age,omitempty"`
 }
 
 type UserItem struct {
-	ID       uint32 `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	ID       publicid.ID `json:"id"`
+	Username string      `json:"username"`
+}
+
+// UpdateProfile only change the fields present in the body, an empty string
+// clear the field.
+type UpdateProfile struct {
+	DisplayName *string `json:"display_name" validate:"omitempty,max=50"`
+	Bio         *string `json:"bio" validate:"omitempty,max=300"`
+	Website     *string `json:"website" validate:"omitempty,max=200"`
+	Pronouns    *string `json:"pronouns" validate:"omitempty,max=30"`
+}
+
+type UserProfile struct {
+	ID             publicid.ID       `json:"id"`
+	Username       string            `json:"username"`
+	DisplayName    string            `json:"display_name"`
+	Bio            string            `json:"bio"`
+	AvatarUrl      string            `json:"avatar_url"`
+	Website        string            `json:"website"`
+	Pronouns       string            `json:"pronouns"`
+	PhotoCount     int64             `json:"photo_count"`
+	FollowerCount  int64             `json:"follower_count"`
+	FollowingCount int64             `json:"following_count"`
+	SocialMedias   []SocialMediaView `json:"social_medias" gorm:"-"`
+	CreatedAt      time.Time         `json:"created_at"`
+	Privacy        PrivacySettings   `json:"-" gorm:"embedded;embeddedPrefix:privacy_"`
+}
+
+type UserSuggestion struct {
+	ID       publicid.ID `json:"id"`
+	Username string      `json:"username"`
+}
+
+func IsReservedUsername(username string) bool {
+	username = strings.ToLower(username)
+	for _, reserved := range ReservedUsernames {
+		if username == reserved {
+			return true
+		}
+	}
+	return false
 }
 
 func (u *User) BeforeCreate(db *gorm.DB) (err error) {
 	if u.ID == 0 {
-		u.ID = uuid.New().ID()
+		u.ID = publicid.ID(snowflake.NextID())
 	}
 	return
 }
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
+	"gorm.io/gorm"
+)
+
+const (
+	WebhookEventPhotoCreated   = "photo.created"
+	WebhookEventCommentCreated = "comment.created"
+	WebhookEventUserDeleted    = "user.deleted"
+)
+
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySuccess = "success"
+	WebhookDeliveryFailed  = "failed"
+)
+
+type Webhook struct {
+	ID        publicid.ID `json:"id"`
+	UserId    publicid.ID `json:"user_id"`
+	Url       string      `json:"url"`
+	Secret    string      `json:"-"`
+	Events    []string    `json:"events" gorm:"serializer:json"`
+	Active    bool        `json:"active"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	DeletedAt gorm.DeletedAt
+}
+
+type WebhookDelivery struct {
+	ID            publicid.ID `json:"id"`
+	WebhookId     publicid.ID `json:"webhook_id"`
+	Event         string      `json:"event"`
+	Payload       string      `json:"payload"`
+	Status        string      `json:"status"`
+	Attempts      int         `json:"attempts"`
+	ResponseCode  *int        `json:"response_code"`
+	ResponseBody  string      `json:"response_body"`
+	Error         string      `json:"error"`
+	NextAttemptAt *time.Time  `json:"next_attempt_at"`
+	DeliveredAt   *time.Time  `json:"delivered_at"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// WebhookPayload is the JSON body posted to the webhook url.
+type WebhookPayload struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+type CreateWebhook struct {
+	Url    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1"`
+}
+
+type UpdateWebhook struct {
+	Url    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1"`
+	Active bool     `json:"active"`
+}
+
+type WebhookRes struct {
+	ID        publicid.ID `json:"id"`
+	UserId    publicid.ID `json:"user_id"`
+	Url       string      `json:"url"`
+	Events    []string    `json:"events"`
+	Active    bool        `json:"active"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// WebhookCreateRes is the only response containing the webhook secret, the
+// secret cannot be read again after the creation.
+type WebhookCreateRes struct {
+	WebhookRes
+	Secret string `json:"secret"`
+}
+
+func (w WebhookDelivery) SortKey(sort string) (int64, publicid.ID) {
+	return pagination.TimeValue(w.CreatedAt), w.ID
+}
+
+func (w *Webhook) BeforeCreate(db *gorm.DB) (err error) {
+	if w.ID == 0 {
+		w.ID = publicid.ID(snowflake.NextID())
+	}
+	return
+}
+
+func (w *WebhookDelivery) BeforeCreate(db *gorm.DB) (err error) {
+	if w.ID == 0 {
+		w.ID = publicid.ID(snowflake.NextID())
+	}
+	return
+}
+
+func IsValidWebhookEvent(event string) bool {
+	switch event {
+	case WebhookEventPhotoCreated, WebhookEventCommentCreated, WebhookEventUserDeleted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm"
+)
+
+// albumPhotosPrimaryKey is the constraint keeping a photo once in an album.
+const albumPhotosPrimaryKey = "album_photos_pkey"
+
+var ErrPhotoAlreadyInAlbum = errors.New("photo already in the album")
+
+const albumPhotoCountQuery = `albums.*, (
+	SELECT COUNT(*) FROM album_photos
+	JOIN photos ON photos.id = album_photos.photo_id AND photos.deleted_at IS NULL
+	WHERE album_photos.album_id = albums.id
+) AS photo_count`
+
+var albumSortKeys = pagination.SortKeys{
+	pagination.SortNewest: {Column: "albums.created_at", Desc: true, Time: true},
+	pagination.SortOldest: {Column: "albums.created_at", Time: true},
+}
+
+type AlbumRepository interface {
+	CreateAlbum(ctx context.Context, album *model.Album) error
+	GetAllAlbumsByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) ([]model.AlbumView, error)
+	GetAlbumById(ctx context.Context, albumId publicid.ID) (*model.AlbumView, error)
+	UpdateAlbum(ctx context.Context, album *model.Album) error
+	DeleteAlbum(ctx context.Context, albumId publicid.ID) error
+	GetAlbumPhotos(ctx context.Context, albumId publicid.ID) ([]model.AlbumPhotoView, error)
+	AddPhotoToAlbum(ctx context.Context, albumId publicid.ID, photoId publicid.ID) error
+	RemovePhotoFromAlbum(ctx context.Context, albumId publicid.ID, photoId publicid.ID) error
+	ReorderAlbumPhotos(ctx context.Context, albumId publicid.ID, photoIds []publicid.ID) error
+}
+
+type albumRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewAlbumRepository(db infrastructure.GormPostgres) AlbumRepository {
+	return &albumRepositoryImpl{db: db}
+}
+
+func (a *albumRepositoryImpl) CreateAlbum(ctx context.Context, album *model.Album) error {
+	db := a.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("albums").
+		Create(&album).
+		Error
+
+	return err
+}
+
+func (a *albumRepositoryImpl) GetAllAlbumsByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) ([]model.AlbumView, error) {
+	db := a.db.GetConnection(ctx)
+	albums := []model.AlbumView{}
+
+	query := db.
+		WithContext(ctx).
+		Table("albums").
+		Select(albumPhotoCountQuery).
+		Where("albums.user_id = ?", userId).
+		Where("albums.deleted_at IS NULL")
+
+	err := pagination.Apply(query, params, albumSortKeys, "albums.id").
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Preload("CoverPhoto", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, title, caption, photo_url, user_id").Table("photos").Where("deleted_at is null")
+		}).
+		Find(&albums).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return albums, nil
+}
+
+func (a *albumRepositoryImpl) GetAlbumById(ctx context.Context, albumId publicid.ID) (*model.AlbumView, error) {
+	db := a.db.GetConnection(ctx)
+	album := model.AlbumView{}
+
+	err := db.
+		WithContext(ctx).
+		Table("albums").
+		Select(albumPhotoCountQuery).
+		Where("albums.id = ?", albumId).
+		Where("albums.deleted_at IS NULL").
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Preload("CoverPhoto", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, title, caption, photo_url, user_id").Table("photos").Where("deleted_at is null")
+		}).
+		Find(&album).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &album, nil
+}
+
+func (a *albumRepositoryImpl) UpdateAlbum(ctx context.Context, album *model.Album) error {
+	db := a.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Model(album).
+		Select("title", "description", "cover_photo_id", "updated_at").
+		Updates(album).
+		Error
+
+	return err
+}
+
+func (a *albumRepositoryImpl) DeleteAlbum(ctx context.Context, albumId publicid.ID) error {
+	db := a.db.GetConnection(ctx)
+	album := model.Album{ID: albumId}
+
+	err := db.
+		WithContext(ctx).
+		Model(&album).
+		Delete(&album).
+		Error
+
+	return err
+}
+
+func (a *albumRepositoryImpl) GetAlbumPhotos(ctx context.Context, albumId publicid.ID) ([]model.AlbumPhotoView, error) {
+	db := a.db.GetConnection(ctx)
+	albumPhotos := []model.AlbumPhotoView{}
+
+	err := db.
+		WithContext(ctx).
+		Table("album_photos").
+		Select("album_photos.*").
+		Joins("JOIN photos ON photos.id = album_photos.photo_id AND photos.deleted_at IS NULL").
+		Where("album_photos.album_id = ?", albumId).
+		Order("album_photos.position ASC").
+		Preload("Photo", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, title, caption, photo_url, user_id").Table("photos")
+		}).
+		Find(&albumPhotos).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return albumPhotos, nil
+}
+
+// AddPhotoToAlbum add the photo after the last photo of the album, the album
+// row is locked so the photos added at once get different positions.
+func (a *albumRepositoryImpl) AddPhotoToAlbum(ctx context.Context, albumId publicid.ID, photoId publicid.ID) error {
+	db := a.db.GetConnection(ctx)
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := lockAlbum(tx, albumId)
+		if err != nil {
+			return err
+		}
+
+		var lastPosition int
+		err = tx.
+			Table("album_photos").
+			Select("COALESCE(MAX(position), 0)").
+			Where("album_id = ?", albumId).
+			Scan(&lastPosition).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		albumPhoto := model.AlbumPhoto{AlbumId: albumId, PhotoId: photoId, Position: lastPosition + 1}
+
+		return tx.
+			Table("album_photos").
+			Create(&albumPhoto).
+			Error
+	})
+
+	return translateAlbumPhotoError(err)
+}
+
+func (a *albumRepositoryImpl) RemovePhotoFromAlbum(ctx context.Context, albumId publicid.ID, photoId publicid.ID) error {
+	db := a.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("album_photos").
+		Where("album_id = ? AND photo_id = ?", albumId, photoId).
+		Delete(&model.AlbumPhoto{}).
+		Error
+
+	return err
+}
+
+func (a *albumRepositoryImpl) ReorderAlbumPhotos(ctx context.Context, albumId publicid.ID, photoIds []publicid.ID) error {
+	db := a.db.GetConnection(ctx)
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := lockAlbum(tx, albumId)
+		if err != nil {
+			return err
+		}
+
+		for i, photoId := range photoIds {
+			err := tx.
+				Table("album_photos").
+				Where("album_id = ? AND photo_id = ?", albumId, photoId).
+				Update("position", i+1).
+				Error
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// lockAlbum lock the album row until the end of tx, the changes of the photo
+// positions of an album are serialized on it.
+func lockAlbum(tx *gorm.DB, albumId publicid.ID) error {
+	return tx.Exec("SELECT id FROM albums WHERE id = ? FOR UPDATE", albumId).Error
+}
+
+// translateAlbumPhotoError turn the violation of the album_photos primary key,
+// which happen when the same photo is added by two requests at once, into
+// ErrPhotoAlreadyInAlbum.
+func translateAlbumPhotoError(err error) error {
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == albumPhotosPrimaryKey {
+		return ErrPhotoAlreadyInAlbum
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	mocks "github.com/zikri124/mygram-api/internal/infrastructure/mock"
+)
+
+func TestAddPhotoToAlbum(t *testing.T) {
+	t.Run("photo is added after the last one once the album is locked", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT id FROM albums WHERE id = $1 FOR UPDATE`)).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(position), 0) FROM "album_photos" WHERE album_id = $1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "album_photos"`)).
+			WithArgs(1, 2, 3, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		albumRepo := albumRepositoryImpl{db: postgresMock}
+		err := albumRepo.AddPhotoToAlbum(context.Background(), 1, 2)
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("photo added at once by another request is a conflict", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT id FROM albums WHERE id = $1 FOR UPDATE`)).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(position), 0) FROM "album_photos" WHERE album_id = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "album_photos"`)).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: albumPhotosPrimaryKey})
+		mock.ExpectRollback()
+
+		albumRepo := albumRepositoryImpl{db: postgresMock}
+		err := albumRepo.AddPhotoToAlbum(context.Background(), 1, 2)
+		assert.ErrorIs(t, err, ErrPhotoAlreadyInAlbum)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("other errors are returned as is", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT id FROM albums WHERE id = $1 FOR UPDATE`)).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(position), 0) FROM "album_photos" WHERE album_id = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "album_photos"`)).
+			WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "album_photos_photo_id_fkey"})
+		mock.ExpectRollback()
+
+		albumRepo := albumRepositoryImpl{db: postgresMock}
+		err := albumRepo.AddPhotoToAlbum(context.Background(), 1, 2)
+		assert.NotErrorIs(t, err, ErrPhotoAlreadyInAlbum)
+		assert.NotNil(t, err)
+	})
+}
@@ -2,18 +2,52 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/zikri124/mygram-api/internal/infrastructure"
 	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 	"gorm.io/gorm"
 )
 
+// visibleCommentCondition match the comments of table which are not deleted,
+// or deleted but still having a not deleted reply anywhere below them.
+func visibleCommentCondition(table string) string {
+	return fmt.Sprintf(`(%[1]s.deleted_at IS NULL OR EXISTS (
+		WITH RECURSIVE descendants AS (
+			SELECT c.id, c.deleted_at FROM comments AS c WHERE c.parent_id = %[1]s.id
+			UNION ALL
+			SELECT c.id, c.deleted_at FROM comments AS c JOIN descendants AS d ON c.parent_id = d.id
+		)
+		SELECT 1 FROM descendants WHERE descendants.deleted_at IS NULL
+	))`, table)
+}
+
+var (
+	commentReplyCountColumn = `(
+	SELECT COUNT(*) FROM comments AS replies
+	WHERE replies.parent_id = comments.id AND ` + visibleCommentCondition("replies") + `
+)`
+	commentLikeCountColumn = `(SELECT COUNT(*) FROM reactions WHERE reactions.target_type = 'comment' AND reactions.target_id = comments.id)`
+	commentReplyCountQuery = `comments.*, ` + commentReplyCountColumn + ` AS reply_count, ` + commentLikeCountColumn + ` AS like_count`
+)
+
+var commentSortKeys = pagination.SortKeys{
+	pagination.SortNewest:        {Column: "comments.created_at", Desc: true, Time: true},
+	pagination.SortOldest:        {Column: "comments.created_at", Time: true},
+	pagination.SortMostLiked:     {Column: commentLikeCountColumn, Desc: true},
+	pagination.SortMostCommented: {Column: commentReplyCountColumn, Desc: true},
+}
+
 type CommentRepository interface {
 	CreateComment(ctx context.Context, comment *model.Comment) error
-	GetAllCommentsByPhotoId(ctx context.Context, photoId uint32) ([]model.CommentView, error)
-	GetCommentById(ctx context.Context, commentId uint32) (*model.CommentView, error)
+	GetAllCommentsByPhotoId(ctx context.Context, photoId publicid.ID, params pagination.Params) ([]model.CommentView, error)
+	GetRepliesByCommentId(ctx context.Context, commentId publicid.ID, params pagination.Params) ([]model.CommentView, error)
+	GetCommentById(ctx context.Context, commentId publicid.ID) (*model.CommentView, error)
+	CountReplies(ctx context.Context, commentId publicid.ID) (int64, error)
 	UpdateComment(ctx context.Context, comment *model.Comment) error
-	DeleteComment(ctx context.Context, commentId uint32) error
+	DeleteComment(ctx context.Context, commentId publicid.ID) error
 }
 
 type commentRepositoryImpl struct {
@@ -25,7 +59,7 @@ func NewCommentRepository(db infrastructure.GormPostgres) CommentRepository {
 }
 
 func (c *commentRepositoryImpl) CreateComment(ctx context.Context, comment *model.Comment) error {
-	db := c.db.GetConnection()
+	db := c.db.GetConnection(ctx)
 
 	err := db.
 		WithContext(ctx).
@@ -36,21 +70,33 @@ func (c *commentRepositoryImpl) CreateComment(ctx context.Context, comment *mode
 	return err
 }
 
-func (c *commentRepositoryImpl) GetAllCommentsByPhotoId(ctx context.Context, photoId uint32) ([]model.CommentView, error) {
-	db := c.db.GetConnection()
+// GetAllCommentsByPhotoId return the top level comments of a photo, the
+// deleted comments having replies are included to keep the threads.
+func (c *commentRepositoryImpl) GetAllCommentsByPhotoId(ctx context.Context, photoId publicid.ID, params pagination.Params) ([]model.CommentView, error) {
+	db := c.db.GetConnection(ctx)
 	comments := []model.CommentView{}
 
-	err := db.
+	query := db.
 		WithContext(ctx).
 		Table("comments").
-		Where("photo_id = ?", photoId).
-		Where("deleted_at IS NULL").
+		Select(commentReplyCountQuery).
+		Where("comments.photo_id = ?", photoId).
+		Where("comments.parent_id IS NULL").
+		Where(visibleCommentCondition("comments"))
+
+	err := pagination.Apply(query, params, commentSortKeys, "comments.id").
 		Preload("User", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id, email, username").Table("users").Where("deleted_at is null")
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
 		}).
 		Preload("Photo", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, title, caption, photo_url, user_id").Table("photos").Where("deleted_at is null")
 		}).
+		Preload("Mentions", func(db *gorm.DB) *gorm.DB {
+			return db.Table("mentions").Order("text_offset ASC")
+		}).
+		Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
 		Find(&comments).
 		Error
 
@@ -61,22 +107,63 @@ func (c *commentRepositoryImpl) GetAllCommentsByPhotoId(ctx context.Context, pho
 	return comments, nil
 }
 
-func (c *commentRepositoryImpl) GetCommentById(ctx context.Context, commentId uint32) (*model.CommentView, error) {
-	db := c.db.GetConnection()
+func (c *commentRepositoryImpl) GetRepliesByCommentId(ctx context.Context, commentId publicid.ID, params pagination.Params) ([]model.CommentView, error) {
+	db := c.db.GetConnection(ctx)
+	replies := []model.CommentView{}
+
+	query := db.
+		WithContext(ctx).
+		Table("comments").
+		Select(commentReplyCountQuery).
+		Where("comments.parent_id = ?", commentId).
+		Where(visibleCommentCondition("comments"))
+
+	err := pagination.Apply(query, params, commentSortKeys, "comments.id").
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Preload("Photo", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, title, caption, photo_url, user_id").Table("photos").Where("deleted_at is null")
+		}).
+		Preload("Mentions", func(db *gorm.DB) *gorm.DB {
+			return db.Table("mentions").Order("text_offset ASC")
+		}).
+		Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Find(&replies).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return replies, nil
+}
+
+func (c *commentRepositoryImpl) GetCommentById(ctx context.Context, commentId publicid.ID) (*model.CommentView, error) {
+	db := c.db.GetConnection(ctx)
 	comment := model.CommentView{}
 	commentModel := model.Comment{}
 
 	err := db.
 		WithContext(ctx).
 		Model(&commentModel).
-		Where("id = ?", commentId).
-		Where("deleted_at IS NULL").
+		Select(commentReplyCountQuery).
+		Where("comments.id = ?", commentId).
+		Where("comments.deleted_at IS NULL").
 		Preload("User", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id, email, username").Table("users").Where("deleted_at is null")
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
 		}).
 		Preload("Photo", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, title, caption, photo_url, user_id").Table("photos").Where("deleted_at is null")
 		}).
+		Preload("Mentions", func(db *gorm.DB) *gorm.DB {
+			return db.Table("mentions").Order("text_offset ASC")
+		}).
+		Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
 		Find(&comment).
 		Error
 
@@ -87,8 +174,29 @@ func (c *commentRepositoryImpl) GetCommentById(ctx context.Context, commentId ui
 	return &comment, nil
 }
 
+// CountReplies count the visible replies of a comment, the comment itself may
+// be deleted.
+func (c *commentRepositoryImpl) CountReplies(ctx context.Context, commentId publicid.ID) (int64, error) {
+	db := c.db.GetConnection(ctx)
+	var count int64
+
+	err := db.
+		WithContext(ctx).
+		Table("comments AS replies").
+		Where("replies.parent_id = ?", commentId).
+		Where(visibleCommentCondition("replies")).
+		Count(&count).
+		Error
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (c *commentRepositoryImpl) UpdateComment(ctx context.Context, comment *model.Comment) error {
-	db := c.db.GetConnection()
+	db := c.db.GetConnection(ctx)
 	err := db.
 		WithContext(ctx).
 		Updates(&comment).
@@ -97,8 +205,8 @@ func (c *commentRepositoryImpl) UpdateComment(ctx context.Context, comment *mode
 	return err
 }
 
-func (c *commentRepositoryImpl) DeleteComment(ctx context.Context, commentId uint32) error {
-	db := c.db.GetConnection()
+func (c *commentRepositoryImpl) DeleteComment(ctx context.Context, commentId publicid.ID) error {
+	db := c.db.GetConnection(ctx)
 	comment := model.Comment{ID: commentId}
 
 	err := db.
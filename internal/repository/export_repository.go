@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+type ExportRepository interface {
+	CreateExport(ctx context.Context, export *model.DataExport) error
+	GetExportById(ctx context.Context, exportId publicid.ID) (*model.DataExport, error)
+	GetPendingExportByUserId(ctx context.Context, userId publicid.ID) (*model.DataExport, error)
+	ClaimPendingExports(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.DataExport, error)
+	GetExpiredExports(ctx context.Context, now time.Time) ([]model.DataExport, error)
+	UpdateExport(ctx context.Context, export *model.DataExport) error
+	GetUserPhotos(ctx context.Context, userId publicid.ID) ([]model.Photo, error)
+	GetUserComments(ctx context.Context, userId publicid.ID) ([]model.Comment, error)
+	GetUserSocialMedias(ctx context.Context, userId publicid.ID) ([]model.SocialMedia, error)
+	GetUserFollows(ctx context.Context, userId publicid.ID) (*model.ExportFollows, error)
+	GetUserReactions(ctx context.Context, userId publicid.ID) ([]model.Reaction, error)
+}
+
+type exportRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewExportRepository(db infrastructure.GormPostgres) ExportRepository {
+	return &exportRepositoryImpl{db: db}
+}
+
+func (e *exportRepositoryImpl) CreateExport(ctx context.Context, export *model.DataExport) error {
+	db := e.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("data_exports").
+		Create(&export).
+		Error
+
+	return err
+}
+
+func (e *exportRepositoryImpl) GetExportById(ctx context.Context, exportId publicid.ID) (*model.DataExport, error) {
+	db := e.db.GetConnection(ctx)
+	export := model.DataExport{}
+
+	err := db.
+		WithContext(ctx).
+		Table("data_exports").
+		Where("id = ?", exportId).
+		Find(&export).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+func (e *exportRepositoryImpl) GetPendingExportByUserId(ctx context.Context, userId publicid.ID) (*model.DataExport, error) {
+	db := e.db.GetConnection(ctx)
+	export := model.DataExport{}
+
+	err := db.
+		WithContext(ctx).
+		Table("data_exports").
+		Where("user_id = ? AND status = ?", userId, model.DataExportPending).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&export).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// ClaimPendingExports return the pending exports nobody is building and set
+// their claim until now plus lease, an export whose worker died is claimed
+// again once the lease is over.
+func (e *exportRepositoryImpl) ClaimPendingExports(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.DataExport, error) {
+	db := e.db.GetConnection(ctx)
+	exports := []model.DataExport{}
+
+	err := db.
+		WithContext(ctx).
+		Raw(`UPDATE data_exports SET claimed_until = ?
+			WHERE id IN (
+				SELECT id FROM data_exports
+				WHERE status = ? AND (claimed_until IS NULL OR claimed_until <= ?)
+				ORDER BY created_at ASC
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *`, now.Add(lease), model.DataExportPending, now, limit).
+		Scan(&exports).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (e *exportRepositoryImpl) GetExpiredExports(ctx context.Context, now time.Time) ([]model.DataExport, error) {
+	db := e.db.GetConnection(ctx)
+	exports := []model.DataExport{}
+
+	err := db.
+		WithContext(ctx).
+		Table("data_exports").
+		Where("status = ? AND expires_at <= ?", model.DataExportReady, now).
+		Find(&exports).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (e *exportRepositoryImpl) UpdateExport(ctx context.Context, export *model.DataExport) error {
+	db := e.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Model(export).
+		Select("status", "attempts", "error", "file_name", "download_token", "claimed_until", "expires_at", "completed_at", "updated_at").
+		Updates(export).
+		Error
+
+	return err
+}
+
+func (e *exportRepositoryImpl) GetUserPhotos(ctx context.Context, userId publicid.ID) ([]model.Photo, error) {
+	db := e.db.GetConnection(ctx)
+	photos := []model.Photo{}
+
+	err := db.
+		WithContext(ctx).
+		Model(&model.Photo{}).
+		Where("user_id = ?", userId).
+		Order("created_at ASC").
+		Find(&photos).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+func (e *exportRepositoryImpl) GetUserComments(ctx context.Context, userId publicid.ID) ([]model.Comment, error) {
+	db := e.db.GetConnection(ctx)
+	comments := []model.Comment{}
+
+	err := db.
+		WithContext(ctx).
+		Model(&model.Comment{}).
+		Where("user_id = ?", userId).
+		Order("created_at ASC").
+		Find(&comments).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (e *exportRepositoryImpl) GetUserSocialMedias(ctx context.Context, userId publicid.ID) ([]model.SocialMedia, error) {
+	db := e.db.GetConnection(ctx)
+	socials := []model.SocialMedia{}
+
+	err := db.
+		WithContext(ctx).
+		Model(&model.SocialMedia{}).
+		Where("user_id = ?", userId).
+		Order("created_at ASC").
+		Find(&socials).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return socials, nil
+}
+
+func (e *exportRepositoryImpl) GetUserFollows(ctx context.Context, userId publicid.ID) (*model.ExportFollows, error) {
+	db := e.db.GetConnection(ctx)
+	follows := model.ExportFollows{Followers: []model.Follow{}, Following: []model.Follow{}}
+
+	err := db.
+		WithContext(ctx).
+		Table("follows").
+		Where("followee_id = ?", userId).
+		Order("created_at ASC").
+		Find(&follows.Followers).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.
+		WithContext(ctx).
+		Table("follows").
+		Where("follower_id = ?", userId).
+		Order("created_at ASC").
+		Find(&follows.Following).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &follows, nil
+}
+
+func (e *exportRepositoryImpl) GetUserReactions(ctx context.Context, userId publicid.ID) ([]model.Reaction, error) {
+	db := e.db.GetConnection(ctx)
+	reactions := []model.Reaction{}
+
+	err := db.
+		WithContext(ctx).
+		Table("reactions").
+		Where("user_id = ?", userId).
+		Order("created_at ASC").
+		Find(&reactions).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reactions, nil
+}
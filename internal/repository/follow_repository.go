@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm/clause"
+)
+
+type FollowRepository interface {
+	CreateFollow(ctx context.Context, follow *model.Follow) (bool, error)
+	DeleteFollow(ctx context.Context, followerId publicid.ID, followeeId publicid.ID) error
+}
+
+type followRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewFollowRepository(db infrastructure.GormPostgres) FollowRepository {
+	return &followRepositoryImpl{db: db}
+}
+
+// CreateFollow return false when the follow already existed.
+func (f *followRepositoryImpl) CreateFollow(ctx context.Context, follow *model.Follow) (bool, error) {
+	db := f.db.GetConnection(ctx)
+
+	result := db.
+		WithContext(ctx).
+		Table("follows").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&follow)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (f *followRepositoryImpl) DeleteFollow(ctx context.Context, followerId publicid.ID, followeeId publicid.ID) error {
+	db := f.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("follows").
+		Where("follower_id = ? AND followee_id = ?", followerId, followeeId).
+		Delete(&model.Follow{}).
+		Error
+
+	return err
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm"
+)
+
+// sourceVisibleConditions match when the photo or the comment given as
+// argument is visible, a comment is hidden with its photo.
+var sourceVisibleConditions = map[string]string{
+	model.MentionSourcePhoto: `EXISTS (SELECT 1 FROM photos WHERE photos.id = ? AND photos.deleted_at IS NULL)`,
+	model.MentionSourceComment: `EXISTS (
+		SELECT 1 FROM comments JOIN photos ON photos.id = comments.photo_id
+		WHERE comments.id = ? AND comments.deleted_at IS NULL AND photos.deleted_at IS NULL
+	)`,
+}
+
+type MentionRepository interface {
+	GetUsersSeeingSource(ctx context.Context, sourceType string, sourceId publicid.ID, userIds []publicid.ID) ([]publicid.ID, error)
+	GetMentionsBySource(ctx context.Context, sourceType string, sourceId publicid.ID) ([]model.MentionView, error)
+	ReplaceMentions(ctx context.Context, sourceType string, sourceId publicid.ID, mentions []model.Mention) error
+}
+
+type mentionRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewMentionRepository(db infrastructure.GormPostgres) MentionRepository {
+	return &mentionRepositoryImpl{db: db}
+}
+
+// GetUsersSeeingSource return the users of userIds who can see the source,
+// the users with a pending account deletion see nothing.
+func (m *mentionRepositoryImpl) GetUsersSeeingSource(ctx context.Context, sourceType string, sourceId publicid.ID, userIds []publicid.ID) ([]publicid.ID, error) {
+	db := m.db.GetConnection(ctx)
+	ids := []publicid.ID{}
+
+	condition, ok := sourceVisibleConditions[sourceType]
+	if !ok || len(userIds) == 0 {
+		return ids, nil
+	}
+
+	err := db.
+		WithContext(ctx).
+		Table("users").
+		Where("id IN ? AND deleted_at IS NULL", userIds).
+		Where(condition, sourceId).
+		Pluck("id", &ids).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (m *mentionRepositoryImpl) GetMentionsBySource(ctx context.Context, sourceType string, sourceId publicid.ID) ([]model.MentionView, error) {
+	db := m.db.GetConnection(ctx)
+	mentions := []model.MentionView{}
+
+	err := db.
+		WithContext(ctx).
+		Table("mentions").
+		Where("source_type = ? AND source_id = ?", sourceType, sourceId).
+		Order("text_offset ASC").
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Find(&mentions).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mentions, nil
+}
+
+func (m *mentionRepositoryImpl) ReplaceMentions(ctx context.Context, sourceType string, sourceId publicid.ID, mentions []model.Mention) error {
+	db := m.db.GetConnection(ctx)
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Table("mentions").
+			Where("source_type = ? AND source_id = ?", sourceType, sourceId).
+			Delete(&model.Mention{}).
+			Error
+
+		if err != nil || len(mentions) == 0 {
+			return err
+		}
+
+		return tx.
+			Table("mentions").
+			Create(&mentions).
+			Error
+	})
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	mocks "github.com/zikri124/mygram-api/internal/infrastructure/mock"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+func TestGetUsersSeeingSource(t *testing.T) {
+	t.Run("comment hidden with its photo", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "users" WHERE (id IN ($1,$2) AND deleted_at IS NULL) AND (EXISTS (
+		SELECT 1 FROM comments JOIN photos ON photos.id = comments.photo_id
+		WHERE comments.id = $3 AND comments.deleted_at IS NULL AND photos.deleted_at IS NULL
+	))`)).
+			WithArgs(publicid.ID(10), publicid.ID(11), publicid.ID(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+
+		mentionRepo := mentionRepositoryImpl{db: postgresMock}
+		ids, err := mentionRepo.GetUsersSeeingSource(context.Background(), model.MentionSourceComment, 5, []publicid.ID{10, 11})
+		assert.Nil(t, err)
+		assert.Equal(t, []publicid.ID{11}, ids)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
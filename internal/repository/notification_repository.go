@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm"
+)
+
+var notificationSortKeys = pagination.SortKeys{
+	pagination.SortNewest: {Column: "created_at", Desc: true, Time: true},
+}
+
+type NotificationRepository interface {
+	CreateNotifications(ctx context.Context, notifications []model.Notification) error
+	GetNotificationsByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) ([]model.NotificationView, error)
+	CountUnreadNotifications(ctx context.Context, userId publicid.ID) (int64, error)
+	MarkNotificationsRead(ctx context.Context, userId publicid.ID, notificationIds []publicid.ID) error
+	MarkAllNotificationsRead(ctx context.Context, userId publicid.ID) error
+}
+
+type notificationRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewNotificationRepository(db infrastructure.GormPostgres) NotificationRepository {
+	return &notificationRepositoryImpl{db: db}
+}
+
+func (n *notificationRepositoryImpl) CreateNotifications(ctx context.Context, notifications []model.Notification) error {
+	db := n.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("notifications").
+		Create(&notifications).
+		Error
+
+	return err
+}
+
+// GetNotificationsByUserId return the newest notifications first.
+func (n *notificationRepositoryImpl) GetNotificationsByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) ([]model.NotificationView, error) {
+	db := n.db.GetConnection(ctx)
+	notifications := []model.NotificationView{}
+
+	query := db.
+		WithContext(ctx).
+		Table("notifications").
+		Where("user_id = ?", userId)
+
+	err := pagination.Apply(query, params, notificationSortKeys, "id").
+		Preload("Actor", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Find(&notifications).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (n *notificationRepositoryImpl) CountUnreadNotifications(ctx context.Context, userId publicid.ID) (int64, error) {
+	db := n.db.GetConnection(ctx)
+	var count int64
+
+	err := db.
+		WithContext(ctx).
+		Table("notifications").
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Count(&count).
+		Error
+
+	return count, err
+}
+
+func (n *notificationRepositoryImpl) MarkNotificationsRead(ctx context.Context, userId publicid.ID, notificationIds []publicid.ID) error {
+	db := n.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("notifications").
+		Where("user_id = ? AND id IN ? AND read_at IS NULL", userId, notificationIds).
+		Update("read_at", time.Now()).
+		Error
+
+	return err
+}
+
+func (n *notificationRepositoryImpl) MarkAllNotificationsRead(ctx context.Context, userId publicid.ID) error {
+	db := n.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("notifications").
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", time.Now()).
+		Error
+
+	return err
+}
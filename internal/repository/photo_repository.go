@@ -2,18 +2,34 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/zikri124/mygram-api/internal/infrastructure"
 	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 	"gorm.io/gorm"
 )
 
+const (
+	photoLikeCountColumn    = `(SELECT COUNT(*) FROM reactions WHERE reactions.target_type = 'photo' AND reactions.target_id = photos.id)`
+	photoCommentCountColumn = `(SELECT COUNT(*) FROM comments WHERE comments.photo_id = photos.id AND comments.deleted_at IS NULL)`
+	photoCountQuery         = `photos.*, ` + photoLikeCountColumn + ` AS like_count, ` + photoCommentCountColumn + ` AS comment_count`
+)
+
+var photoSortKeys = pagination.SortKeys{
+	pagination.SortNewest:        {Column: "photos.created_at", Desc: true, Time: true},
+	pagination.SortOldest:        {Column: "photos.created_at", Time: true},
+	pagination.SortMostLiked:     {Column: photoLikeCountColumn, Desc: true},
+	pagination.SortMostCommented: {Column: photoCommentCountColumn, Desc: true},
+}
+
 type PhotoRepository interface {
 	CreatePhoto(ctx context.Context, photo *model.Photo) error
-	GetAllPhotosByUserId(ctx context.Context, userId uint32) ([]model.PhotoView, error)
-	GetPhotoById(ctx context.Context, photoId uint32) (*model.PhotoView, error)
+	GetAllPhotosByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) ([]model.PhotoView, error)
+	GetPhotoById(ctx context.Context, photoId publicid.ID) (*model.PhotoView, error)
 	UpdatePhoto(ctx context.Context, photo *model.Photo) error
-	DeletePhoto(ctx context.Context, photoId uint32) error
+	DeletePhoto(ctx context.Context, photoId publicid.ID) error
 }
 
 type photoRepositoryImpl struct {
@@ -25,7 +41,7 @@ func NewPhotoRepository(db infrastructure.GormPostgres) PhotoRepository {
 }
 
 func (p *photoRepositoryImpl) CreatePhoto(ctx context.Context, photo *model.Photo) error {
-	db := p.db.GetConnection()
+	db := p.db.GetConnection(ctx)
 
 	err := db.
 		WithContext(ctx).
@@ -36,17 +52,26 @@ func (p *photoRepositoryImpl) CreatePhoto(ctx context.Context, photo *model.Phot
 	return err
 }
 
-func (p *photoRepositoryImpl) GetAllPhotosByUserId(ctx context.Context, userId uint32) ([]model.PhotoView, error) {
-	db := p.db.GetConnection()
+func (p *photoRepositoryImpl) GetAllPhotosByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) ([]model.PhotoView, error) {
+	db := p.db.GetConnection(ctx)
 	photos := []model.PhotoView{}
 
-	err := db.
+	query := db.
 		WithContext(ctx).
 		Table("photos").
-		Where("user_id = ?", userId).
-		Where("deleted_at IS NULL").
+		Select(photoCountQuery).
+		Where("photos.user_id = ?", userId).
+		Where("photos.deleted_at IS NULL")
+
+	err := pagination.Apply(query, params, photoSortKeys, "photos.id").
 		Preload("User", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id, email, username").Table("users").Where("deleted_at is null")
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Preload("Mentions", func(db *gorm.DB) *gorm.DB {
+			return db.Table("mentions").Order("text_offset ASC")
+		}).
+		Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
 		}).
 		Find(&photos).
 		Error
@@ -58,18 +83,25 @@ func (p *photoRepositoryImpl) GetAllPhotosByUserId(ctx context.Context, userId u
 	return photos, nil
 }
 
-func (p *photoRepositoryImpl) GetPhotoById(ctx context.Context, photoId uint32) (*model.PhotoView, error) {
-	db := p.db.GetConnection()
+func (p *photoRepositoryImpl) GetPhotoById(ctx context.Context, photoId publicid.ID) (*model.PhotoView, error) {
+	db := p.db.GetConnection(ctx)
 	photoModel := model.Photo{}
 	photo := model.PhotoView{}
 
 	err := db.
 		WithContext(ctx).
 		Model(&photoModel).
-		Where("id = ?", photoId).
-		Where("deleted_at IS NULL").
+		Select(photoCountQuery).
+		Where("photos.id = ?", photoId).
+		Where("photos.deleted_at IS NULL").
 		Preload("User", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id, email, username").Table("users").Where("deleted_at is null")
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Preload("Mentions", func(db *gorm.DB) *gorm.DB {
+			return db.Table("mentions").Order("text_offset ASC")
+		}).
+		Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
 		}).
 		Find(&photo).
 		Error
@@ -82,37 +114,38 @@ func (p *photoRepositoryImpl) GetPhotoById(ctx context.Context, photoId uint32)
 }
 
 func (p *photoRepositoryImpl) UpdatePhoto(ctx context.Context, photo *model.Photo) error {
-	db := p.db.GetConnection()
+	db := p.db.GetConnection(ctx)
 	err := db.
 		WithContext(ctx).
-		Updates(&photo).
+		Model(photo).
+		Select("title", "caption", "photo_url").
+		Updates(photo).
 		Error
 
 	return err
 }
 
-func (p *photoRepositoryImpl) DeletePhoto(ctx context.Context, photoId uint32) error {
-	db := p.db.GetConnection()
-	photo := model.Photo{ID: photoId}
-
-	err := db.
-		WithContext(ctx).
-		Model(&photo).
-		Delete(&photo).
-		Error
-
-	if err != nil {
-		return err
-	}
-
-	comment := model.Comment{}
-
-	err = db.
-		WithContext(ctx).
-		Table("comments").
-		Where("photo_id=?", photoId).
-		Delete(&comment).
-		Error
-
-	return err
+// DeletePhoto soft delete the photo and its comments with the same
+// deleted_at, restoring the photo from the trash restore these comments.
+func (p *photoRepositoryImpl) DeletePhoto(ctx context.Context, photoId publicid.ID) error {
+	db := p.db.GetConnection(ctx)
+	deletedAt := time.Now().Truncate(time.Microsecond)
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Table("photos").
+			Where("id = ? AND deleted_at IS NULL", photoId).
+			Update("deleted_at", deletedAt).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		return tx.
+			Table("comments").
+			Where("photo_id = ? AND deleted_at IS NULL", photoId).
+			Update("deleted_at", deletedAt).
+			Error
+	})
 }
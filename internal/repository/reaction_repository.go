@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm/clause"
+)
+
+type ReactionRepository interface {
+	GetUserReaction(ctx context.Context, targetType string, targetId publicid.ID, userId publicid.ID) (*model.Reaction, error)
+	GetUserReactions(ctx context.Context, targetType string, targetIds []publicid.ID, userId publicid.ID) ([]model.Reaction, error)
+	GetReactionCounts(ctx context.Context, targetType string, targetIds []publicid.ID) ([]model.TargetReactionCount, error)
+	UpsertReaction(ctx context.Context, reaction *model.Reaction) error
+	DeleteReaction(ctx context.Context, targetType string, targetId publicid.ID, userId publicid.ID) error
+}
+
+type reactionRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewReactionRepository(db infrastructure.GormPostgres) ReactionRepository {
+	return &reactionRepositoryImpl{db: db}
+}
+
+func (r *reactionRepositoryImpl) GetUserReaction(ctx context.Context, targetType string, targetId publicid.ID, userId publicid.ID) (*model.Reaction, error) {
+	db := r.db.GetConnection(ctx)
+	reaction := model.Reaction{}
+
+	err := db.
+		WithContext(ctx).
+		Table("reactions").
+		Where("target_type = ? AND target_id = ? AND user_id = ?", targetType, targetId, userId).
+		Find(&reaction).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &reaction, nil
+}
+
+func (r *reactionRepositoryImpl) GetUserReactions(ctx context.Context, targetType string, targetIds []publicid.ID, userId publicid.ID) ([]model.Reaction, error) {
+	db := r.db.GetConnection(ctx)
+	reactions := []model.Reaction{}
+
+	err := db.
+		WithContext(ctx).
+		Table("reactions").
+		Where("target_type = ? AND target_id IN ? AND user_id = ?", targetType, targetIds, userId).
+		Find(&reactions).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reactions, nil
+}
+
+func (r *reactionRepositoryImpl) GetReactionCounts(ctx context.Context, targetType string, targetIds []publicid.ID) ([]model.TargetReactionCount, error) {
+	db := r.db.GetConnection(ctx)
+	counts := []model.TargetReactionCount{}
+
+	err := db.
+		WithContext(ctx).
+		Table("reactions").
+		Select("target_id, emoji, COUNT(*) AS count").
+		Where("target_type = ? AND target_id IN ?", targetType, targetIds).
+		Group("target_id, emoji").
+		Order("count DESC, emoji ASC").
+		Scan(&counts).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// UpsertReaction create the reaction of the user on the target, or change its
+// emoji when the user already reacted.
+func (r *reactionRepositoryImpl) UpsertReaction(ctx context.Context, reaction *model.Reaction) error {
+	db := r.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("reactions").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "target_type"}, {Name: "target_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"emoji", "updated_at"}),
+		}).
+		Create(&reaction).
+		Error
+
+	return err
+}
+
+func (r *reactionRepositoryImpl) DeleteReaction(ctx context.Context, targetType string, targetId publicid.ID, userId publicid.ID) error {
+	db := r.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("reactions").
+		Where("target_type = ? AND target_id = ? AND user_id = ?", targetType, targetId, userId).
+		Delete(&model.Reaction{}).
+		Error
+
+	return err
+}
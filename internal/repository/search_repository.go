@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// The search vectors are expressions so they need no extra column, every
+// expression must stay identical to the one of its GIN expression index or
+// postgres will not use the index. Hashtags are part of the caption.
+const (
+	userSearchVector    = `to_tsvector('simple', users.username)`
+	photoSearchVector   = `(setweight(to_tsvector('simple', photos.title), 'A') || setweight(to_tsvector('simple', photos.caption), 'B'))`
+	commentSearchVector = `to_tsvector('simple', comments.message)`
+)
+
+// The search only match and return the fields every user can see: the
+// username, the photo title and caption and the comment message. The fields
+// behind the privacy settings are never indexed nor returned, and the results
+// of the users with a pending account deletion, the trashed photos and the
+// deleted comments or comments of a trashed photo are left out.
+type SearchRepository interface {
+	SearchUsers(ctx context.Context, tsQuery string, username string, limit int) ([]model.UserSearchItem, error)
+	SearchPhotos(ctx context.Context, tsQuery string, limit int) ([]model.PhotoSearchItem, error)
+	SearchComments(ctx context.Context, tsQuery string, limit int) ([]model.CommentSearchItem, error)
+}
+
+type searchRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewSearchRepository(db infrastructure.GormPostgres) SearchRepository {
+	return &searchRepositoryImpl{db: db}
+}
+
+// SearchUsers rank the user named exactly username first, then by relevance.
+func (s *searchRepositoryImpl) SearchUsers(ctx context.Context, tsQuery string, username string, limit int) ([]model.UserSearchItem, error) {
+	db := s.db.GetConnection(ctx)
+	users := []model.UserSearchItem{}
+
+	err := db.
+		WithContext(ctx).
+		Table("users").
+		Select("users.id, users.username, ts_rank("+userSearchVector+", to_tsquery('simple', ?)) AS rank", tsQuery).
+		Where(userSearchVector+" @@ to_tsquery('simple', ?)", tsQuery).
+		Where("users.deleted_at IS NULL").
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "lower(users.username) = lower(?) DESC, rank DESC, users.username ASC",
+			Vars:               []interface{}{username},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Find(&users).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (s *searchRepositoryImpl) SearchPhotos(ctx context.Context, tsQuery string, limit int) ([]model.PhotoSearchItem, error) {
+	db := s.db.GetConnection(ctx)
+	photos := []model.PhotoSearchItem{}
+
+	err := db.
+		WithContext(ctx).
+		Table("photos").
+		Select("photos.*, ts_rank("+photoSearchVector+", to_tsquery('simple', ?)) AS rank", tsQuery).
+		Joins("JOIN users ON users.id = photos.user_id AND users.deleted_at IS NULL").
+		Where(photoSearchVector+" @@ to_tsquery('simple', ?)", tsQuery).
+		Where("photos.deleted_at IS NULL").
+		Order("rank DESC, photos.created_at DESC").
+		Limit(limit).
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Find(&photos).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+func (s *searchRepositoryImpl) SearchComments(ctx context.Context, tsQuery string, limit int) ([]model.CommentSearchItem, error) {
+	db := s.db.GetConnection(ctx)
+	comments := []model.CommentSearchItem{}
+
+	err := db.
+		WithContext(ctx).
+		Table("comments").
+		Select("comments.*, ts_rank("+commentSearchVector+", to_tsquery('simple', ?)) AS rank", tsQuery).
+		Joins("JOIN users ON users.id = comments.user_id AND users.deleted_at IS NULL").
+		Joins("JOIN photos ON photos.id = comments.photo_id AND photos.deleted_at IS NULL").
+		Joins("JOIN users AS photo_owners ON photo_owners.id = photos.user_id AND photo_owners.deleted_at IS NULL").
+		Where(commentSearchVector+" @@ to_tsquery('simple', ?)", tsQuery).
+		Where("comments.deleted_at IS NULL").
+		Order("rank DESC, comments.created_at DESC").
+		Limit(limit).
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Find(&comments).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
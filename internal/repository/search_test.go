@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	mocks "github.com/zikri124/mygram-api/internal/infrastructure/mock"
+	"github.com/zikri124/mygram-api/internal/migration"
+	"github.com/zikri124/mygram-api/internal/model"
+)
+
+func TestSearchVectorsAreIndexed(t *testing.T) {
+	migrations, err := migration.Load()
+	assert.Nil(t, err)
+
+	schema := ""
+	for _, migration := range migrations {
+		schema += migration.Up
+	}
+
+	for _, vector := range []string{userSearchVector, photoSearchVector, commentSearchVector} {
+		assert.True(t, strings.Contains(schema, "USING gin ("+vector+")"), "no gin index on %s", vector)
+	}
+}
+
+func TestSearchFilters(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("users with a pending deletion are left out", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", ctx).Return(db)
+
+		mock.ExpectQuery(`SELECT users.id, users.username, .* FROM "users" WHERE .* AND users.deleted_at IS NULL ORDER BY`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "rank"}).AddRow(1, "alice", 0.5))
+
+		searchRepo := searchRepositoryImpl{db: postgresMock}
+		users, err := searchRepo.SearchUsers(ctx, "alice:*", "alice", 10)
+		assert.Nil(t, err)
+		assert.Len(t, users, 1)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("trashed photos and photos of deleted users are left out", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", ctx).Return(db)
+
+		mock.ExpectQuery(`FROM "photos" JOIN users ON users.id = photos.user_id AND users.deleted_at IS NULL WHERE .* AND photos.deleted_at IS NULL ORDER BY`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		searchRepo := searchRepositoryImpl{db: postgresMock}
+		photos, err := searchRepo.SearchPhotos(ctx, "sunset:*", 10)
+		assert.Nil(t, err)
+		assert.Empty(t, photos)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("comments are left out with their photo and its owner", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", ctx).Return(db)
+
+		mock.ExpectQuery(`FROM "comments" JOIN users ON users.id = comments.user_id AND users.deleted_at IS NULL ` +
+			`JOIN photos ON photos.id = comments.photo_id AND photos.deleted_at IS NULL ` +
+			`JOIN users AS photo_owners ON photo_owners.id = photos.user_id AND photo_owners.deleted_at IS NULL ` +
+			`WHERE .* AND comments.deleted_at IS NULL ORDER BY`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		searchRepo := searchRepositoryImpl{db: postgresMock}
+		comments, err := searchRepo.SearchComments(ctx, "nice:*", 10)
+		assert.Nil(t, err)
+		assert.Empty(t, comments)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestSearchResultsArePublic guard the privacy settings, a field a user can
+// hide must not be added to the search results.
+func TestSearchResultsArePublic(t *testing.T) {
+	private := map[string]bool{}
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(model.PrivacySettings{})) {
+		name := strings.TrimPrefix(field.Tag.Get("json"), "show_")
+		private[name] = true
+	}
+	private["email"] = true
+	private["dob"] = true
+
+	for _, item := range []any{model.UserSearchItem{}, model.PhotoSearchItem{}, model.CommentSearchItem{}, model.UserItem{}} {
+		for _, field := range reflect.VisibleFields(reflect.TypeOf(item)) {
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			assert.False(t, private[name], "%T return the private field %s", item, name)
+		}
+	}
+}
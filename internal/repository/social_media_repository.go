@@ -5,15 +5,22 @@ import (
 
 	"github.com/zikri124/mygram-api/internal/infrastructure"
 	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 	"gorm.io/gorm"
 )
 
+var socialMediaSortKeys = pagination.SortKeys{
+	pagination.SortNewest: {Column: "created_at", Desc: true, Time: true},
+	pagination.SortOldest: {Column: "created_at", Time: true},
+}
+
 type SocialMediaRepository interface {
 	CreateSocial(ctx context.Context, social *model.SocialMedia) error
-	GetAllSocialMediasByUserId(ctx context.Context, userId uint32) ([]model.SocialMediaView, error)
-	GetSocialById(ctx context.Context, socialId uint32) (*model.SocialMediaView, error)
+	GetAllSocialMediasByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) ([]model.SocialMediaView, error)
+	GetSocialById(ctx context.Context, socialId publicid.ID) (*model.SocialMediaView, error)
 	UpdateSocial(ctx context.Context, social *model.SocialMedia) error
-	DeleteSocial(ctx context.Context, socialId uint32) error
+	DeleteSocial(ctx context.Context, socialId publicid.ID) error
 }
 
 type socialMediaRepositoryImpl struct {
@@ -25,7 +32,7 @@ func NewSocialMediaRepository(db infrastructure.GormPostgres) SocialMediaReposit
 }
 
 func (s *socialMediaRepositoryImpl) CreateSocial(ctx context.Context, social *model.SocialMedia) error {
-	db := s.db.GetConnection()
+	db := s.db.GetConnection(ctx)
 
 	err := db.
 		WithContext(ctx).
@@ -36,17 +43,19 @@ func (s *socialMediaRepositoryImpl) CreateSocial(ctx context.Context, social *mo
 	return err
 }
 
-func (s *socialMediaRepositoryImpl) GetAllSocialMediasByUserId(ctx context.Context, userId uint32) ([]model.SocialMediaView, error) {
-	db := s.db.GetConnection()
+func (s *socialMediaRepositoryImpl) GetAllSocialMediasByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) ([]model.SocialMediaView, error) {
+	db := s.db.GetConnection(ctx)
 	socials := []model.SocialMediaView{}
 
-	err := db.
+	query := db.
 		WithContext(ctx).
 		Table("social_medias").
 		Where("user_id = ?", userId).
-		Where("deleted_at IS NULL").
+		Where("deleted_at IS NULL")
+
+	err := pagination.Apply(query, params, socialMediaSortKeys, "id").
 		Preload("User", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id, email, username").Table("users").Where("deleted_at is null")
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
 		}).
 		Find(&socials).
 		Error
@@ -58,8 +67,8 @@ func (s *socialMediaRepositoryImpl) GetAllSocialMediasByUserId(ctx context.Conte
 	return socials, nil
 }
 
-func (s *socialMediaRepositoryImpl) GetSocialById(ctx context.Context, socialId uint32) (*model.SocialMediaView, error) {
-	db := s.db.GetConnection()
+func (s *socialMediaRepositoryImpl) GetSocialById(ctx context.Context, socialId publicid.ID) (*model.SocialMediaView, error) {
+	db := s.db.GetConnection(ctx)
 	socialModel := model.SocialMedia{}
 	social := model.SocialMediaView{}
 
@@ -70,7 +79,7 @@ func (s *socialMediaRepositoryImpl) GetSocialById(ctx context.Context, socialId
 		Where("id = ?", socialId).
 		Where("deleted_at IS NULL").
 		Preload("User", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id, email, username").Table("users").Where("deleted_at is null")
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
 		}).
 		Find(&social).
 		Error
@@ -83,7 +92,7 @@ func (s *socialMediaRepositoryImpl) GetSocialById(ctx context.Context, socialId
 }
 
 func (s *socialMediaRepositoryImpl) UpdateSocial(ctx context.Context, social *model.SocialMedia) error {
-	db := s.db.GetConnection()
+	db := s.db.GetConnection(ctx)
 	err := db.
 		WithContext(ctx).
 		Table("social_medias").
@@ -93,8 +102,8 @@ func (s *socialMediaRepositoryImpl) UpdateSocial(ctx context.Context, social *mo
 	return err
 }
 
-func (s *socialMediaRepositoryImpl) DeleteSocial(ctx context.Context, socialId uint32) error {
-	db := s.db.GetConnection()
+func (s *socialMediaRepositoryImpl) DeleteSocial(ctx context.Context, socialId publicid.ID) error {
+	db := s.db.GetConnection(ctx)
 	social := model.SocialMedia{ID: socialId}
 
 	err := db.
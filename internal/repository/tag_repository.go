@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepository interface {
+	SyncPhotoTags(ctx context.Context, photoId publicid.ID, names []string) error
+	GetPhotosByTag(ctx context.Context, tag string, params pagination.Params) ([]model.PhotoView, error)
+	CountPhotosByTag(ctx context.Context, tag string) (int64, error)
+	GetTrendingTags(ctx context.Context, since time.Time, limit int) ([]model.TagView, error)
+}
+
+type tagRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewTagRepository(db infrastructure.GormPostgres) TagRepository {
+	return &tagRepositoryImpl{db: db}
+}
+
+// SyncPhotoTags make the tags of a photo exactly match names, creating the
+// tags that do not exist yet and unlinking the ones removed from the caption.
+func (t *tagRepositoryImpl) SyncPhotoTags(ctx context.Context, photoId publicid.ID, names []string) error {
+	db := t.db.GetConnection(ctx)
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tags := []model.Tag{}
+
+		if len(names) > 0 {
+			newTags := []model.Tag{}
+			for _, name := range names {
+				newTags = append(newTags, model.Tag{Name: name})
+			}
+
+			err := tx.
+				Table("tags").
+				Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+				Create(&newTags).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			err = tx.
+				Table("tags").
+				Where("name IN ?", names).
+				Find(&tags).
+				Error
+
+			if err != nil {
+				return err
+			}
+		}
+
+		tagIds := []publicid.ID{}
+		for _, tag := range tags {
+			tagIds = append(tagIds, tag.ID)
+		}
+
+		query := tx.
+			Table("photo_tags").
+			Where("photo_id = ?", photoId)
+
+		if len(tagIds) > 0 {
+			query = query.Where("tag_id NOT IN ?", tagIds)
+		}
+
+		err := query.Delete(&model.PhotoTag{}).Error
+		if err != nil || len(tagIds) == 0 {
+			return err
+		}
+
+		photoTags := []model.PhotoTag{}
+		for _, tagId := range tagIds {
+			photoTags = append(photoTags, model.PhotoTag{PhotoId: photoId, TagId: tagId})
+		}
+
+		return tx.
+			Table("photo_tags").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&photoTags).
+			Error
+	})
+}
+
+func (t *tagRepositoryImpl) GetPhotosByTag(ctx context.Context, tag string, params pagination.Params) ([]model.PhotoView, error) {
+	db := t.db.GetConnection(ctx)
+	photos := []model.PhotoView{}
+
+	query := db.
+		WithContext(ctx).
+		Table("photos").
+		Select(photoCountQuery).
+		Joins("JOIN photo_tags ON photo_tags.photo_id = photos.id").
+		Joins("JOIN tags ON tags.id = photo_tags.tag_id").
+		Where("tags.name = ?", tag).
+		Where("photos.deleted_at IS NULL")
+
+	err := pagination.Apply(query, params, photoSortKeys, "photos.id").
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Preload("Mentions", func(db *gorm.DB) *gorm.DB {
+			return db.Table("mentions").Order("text_offset ASC")
+		}).
+		Preload("Mentions.User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username").Table("users").Where("deleted_at is null")
+		}).
+		Find(&photos).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+func (t *tagRepositoryImpl) CountPhotosByTag(ctx context.Context, tag string) (int64, error) {
+	db := t.db.GetConnection(ctx)
+	var count int64
+
+	err := db.
+		WithContext(ctx).
+		Table("photo_tags").
+		Joins("JOIN tags ON tags.id = photo_tags.tag_id").
+		Joins("JOIN photos ON photos.id = photo_tags.photo_id AND photos.deleted_at IS NULL").
+		Where("tags.name = ?", tag).
+		Count(&count).
+		Error
+
+	return count, err
+}
+
+func (t *tagRepositoryImpl) GetTrendingTags(ctx context.Context, since time.Time, limit int) ([]model.TagView, error) {
+	db := t.db.GetConnection(ctx)
+	tags := []model.TagView{}
+
+	err := db.
+		WithContext(ctx).
+		Table("photo_tags").
+		Select("tags.name AS name, COUNT(*) AS photo_count").
+		Joins("JOIN tags ON tags.id = photo_tags.tag_id").
+		Joins("JOIN photos ON photos.id = photo_tags.photo_id AND photos.deleted_at IS NULL").
+		Where("photo_tags.created_at >= ?", since).
+		Group("tags.name").
+		Order("photo_count DESC, tags.name ASC").
+		Limit(limit).
+		Scan(&tags).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
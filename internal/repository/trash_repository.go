@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm"
+)
+
+// The contents of the accounts being deleted are left to PurgeUser, they are
+// restored with the account.
+const (
+	deletedUsers      = "SELECT id FROM users WHERE deleted_at IS NOT NULL"
+	deletedUserPhotos = "SELECT id FROM photos WHERE user_id IN (" + deletedUsers + ")"
+)
+
+type TrashRepository interface {
+	GetTrashByUserId(ctx context.Context, userId publicid.ID, deletedSince time.Time) ([]model.TrashItem, error)
+	GetDeletedPhoto(ctx context.Context, photoId publicid.ID) (*model.DeletedItem, error)
+	GetDeletedComment(ctx context.Context, commentId publicid.ID) (*model.DeletedItem, error)
+	GetDeletedSocialMedia(ctx context.Context, socialId publicid.ID) (*model.DeletedItem, error)
+	IsPhotoDeleted(ctx context.Context, photoId publicid.ID) (bool, error)
+	RestorePhoto(ctx context.Context, photo model.DeletedItem) error
+	RestoreComment(ctx context.Context, commentId publicid.ID) error
+	RestoreSocialMedia(ctx context.Context, socialId publicid.ID) error
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) ([]string, error)
+}
+
+type trashRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewTrashRepository(db infrastructure.GormPostgres) TrashRepository {
+	return &trashRepositoryImpl{db: db}
+}
+
+// GetTrashByUserId return the items the user deleted after deletedSince, the
+// comments deleted with their photo are restored with the photo so they are
+// not listed.
+func (t *trashRepositoryImpl) GetTrashByUserId(ctx context.Context, userId publicid.ID, deletedSince time.Time) ([]model.TrashItem, error) {
+	db := t.db.GetConnection(ctx)
+	items := []model.TrashItem{}
+
+	err := db.
+		WithContext(ctx).
+		Raw(`SELECT 'photo' AS type, id, title AS preview, deleted_at FROM photos
+				WHERE user_id = @user AND deleted_at > @since
+			UNION ALL
+			SELECT 'comment' AS type, comments.id, comments.message AS preview, comments.deleted_at FROM comments
+				JOIN photos ON photos.id = comments.photo_id
+				WHERE comments.user_id = @user AND comments.deleted_at > @since
+				AND (photos.deleted_at IS NULL OR photos.deleted_at <> comments.deleted_at)
+			UNION ALL
+			SELECT 'social_media' AS type, id, name AS preview, deleted_at FROM social_medias
+				WHERE user_id = @user AND deleted_at > @since
+			ORDER BY deleted_at DESC, id DESC`, sql.Named("user", userId), sql.Named("since", deletedSince)).
+		Scan(&items).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (t *trashRepositoryImpl) GetDeletedPhoto(ctx context.Context, photoId publicid.ID) (*model.DeletedItem, error) {
+	return t.getDeletedItem(ctx, "photos", "id, user_id, id AS photo_id, deleted_at", photoId)
+}
+
+func (t *trashRepositoryImpl) GetDeletedComment(ctx context.Context, commentId publicid.ID) (*model.DeletedItem, error) {
+	return t.getDeletedItem(ctx, "comments", "id, user_id, photo_id, deleted_at", commentId)
+}
+
+func (t *trashRepositoryImpl) GetDeletedSocialMedia(ctx context.Context, socialId publicid.ID) (*model.DeletedItem, error) {
+	return t.getDeletedItem(ctx, "social_medias", "id, user_id, 0 AS photo_id, deleted_at", socialId)
+}
+
+func (t *trashRepositoryImpl) getDeletedItem(ctx context.Context, table string, columns string, id publicid.ID) (*model.DeletedItem, error) {
+	db := t.db.GetConnection(ctx)
+	item := model.DeletedItem{}
+
+	err := db.
+		WithContext(ctx).
+		Table(table).
+		Select(columns).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Find(&item).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (t *trashRepositoryImpl) IsPhotoDeleted(ctx context.Context, photoId publicid.ID) (bool, error) {
+	db := t.db.GetConnection(ctx)
+	var count int64
+
+	err := db.
+		WithContext(ctx).
+		Table("photos").
+		Where("id = ? AND deleted_at IS NOT NULL", photoId).
+		Count(&count).
+		Error
+
+	return count > 0, err
+}
+
+// RestorePhoto restore the photo and the comments deleted with it.
+func (t *trashRepositoryImpl) RestorePhoto(ctx context.Context, photo model.DeletedItem) error {
+	db := t.db.GetConnection(ctx)
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Table("photos").
+			Where("id = ?", photo.ID).
+			Update("deleted_at", nil).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		return tx.
+			Table("comments").
+			Where("photo_id = ? AND deleted_at = ?", photo.ID, photo.DeletedAt).
+			Update("deleted_at", nil).
+			Error
+	})
+}
+
+func (t *trashRepositoryImpl) RestoreComment(ctx context.Context, commentId publicid.ID) error {
+	db := t.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("comments").
+		Where("id = ?", commentId).
+		Update("deleted_at", nil).
+		Error
+
+	return err
+}
+
+func (t *trashRepositoryImpl) RestoreSocialMedia(ctx context.Context, socialId publicid.ID) error {
+	db := t.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("social_medias").
+		Where("id = ?", socialId).
+		Update("deleted_at", nil).
+		Error
+
+	return err
+}
+
+// PurgeTrash hard delete the photos, comments and social medias deleted
+// before deletedBefore and return the urls of the purged photos. A deleted
+// comment is only purged once it has no reply left, until then it is the
+// placeholder of its thread.
+func (t *trashRepositoryImpl) PurgeTrash(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	db := t.db.GetConnection(ctx)
+	photoUrls := []string{}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		photos := []struct {
+			ID       publicid.ID
+			PhotoUrl string
+		}{}
+
+		err := tx.
+			Table("photos").
+			Select("id, photo_url").
+			Where("deleted_at <= ? AND user_id NOT IN ("+deletedUsers+")", deletedBefore).
+			Find(&photos).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		photoIds := []publicid.ID{}
+		for _, photo := range photos {
+			photoIds = append(photoIds, photo.ID)
+			photoUrls = append(photoUrls, photo.PhotoUrl)
+		}
+
+		if len(photoIds) > 0 {
+			photoComments := "SELECT id FROM comments WHERE photo_id IN @photos"
+			statements := []string{
+				"DELETE FROM reactions WHERE (target_type = 'photo' AND target_id IN @photos) OR (target_type = 'comment' AND target_id IN (" + photoComments + "))",
+				"DELETE FROM mentions WHERE (source_type = 'photo' AND source_id IN @photos) OR (source_type = 'comment' AND source_id IN (" + photoComments + "))",
+				"DELETE FROM photo_tags WHERE photo_id IN @photos",
+				"DELETE FROM album_photos WHERE photo_id IN @photos",
+				"UPDATE albums SET cover_photo_id = NULL WHERE cover_photo_id IN @photos",
+				"DELETE FROM comments WHERE photo_id IN @photos",
+				"DELETE FROM photos WHERE id IN @photos",
+			}
+
+			for _, statement := range statements {
+				err = tx.Exec(statement, sql.Named("photos", photoIds)).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		commentIds := []publicid.ID{}
+		err = tx.
+			Table("comments").
+			Where("deleted_at <= ? AND user_id NOT IN ("+deletedUsers+") AND photo_id NOT IN ("+deletedUserPhotos+")", deletedBefore).
+			Where("NOT EXISTS (SELECT 1 FROM comments AS replies WHERE replies.parent_id = comments.id)").
+			Pluck("id", &commentIds).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		if len(commentIds) > 0 {
+			statements := []string{
+				"DELETE FROM reactions WHERE target_type = 'comment' AND target_id IN @comments",
+				"DELETE FROM mentions WHERE source_type = 'comment' AND source_id IN @comments",
+				"DELETE FROM comments WHERE id IN @comments",
+			}
+
+			for _, statement := range statements {
+				err = tx.Exec(statement, sql.Named("comments", commentIds)).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.
+			Exec("DELETE FROM social_medias WHERE deleted_at <= ? AND user_id NOT IN ("+deletedUsers+")", deletedBefore).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return photoUrls, nil
+}
@@ -2,17 +2,46 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/zikri124/mygram-api/internal/infrastructure"
 	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// userOwnedTables are soft deleted with the account, the comments on the
+// photos of the user are deleted with the photos.
+var userOwnedTables = []string{"photos", "comments", "social_medias", "albums"}
+
+// usernameUniqueIndex is the unique index on lower(username) making the
+// usernames case-insensitively unique.
+const usernameUniqueIndex = "users_username_lower_key"
+
+var ErrUsernameTaken = errors.New("username already exist")
+
 type UserRepository interface {
-	GetUserById(ctx context.Context, userId uint32) (model.User, error)
+	GetUserById(ctx context.Context, userId publicid.ID) (model.User, error)
 	CreateUser(ctx context.Context, user *model.User) error
 	GetUserByEmail(ctx context.Context, email string) (model.User, error)
+	GetUsersByUsernames(ctx context.Context, usernames []string) ([]model.User, error)
+	GetUserByUsername(ctx context.Context, username string) (model.User, error)
+	GetUsernameSuggestions(ctx context.Context, prefix string, limit int) ([]model.UserSuggestion, error)
+	GetUserProfile(ctx context.Context, userId publicid.ID) (model.UserProfile, error)
 	EditUser(ctx context.Context, user *model.User) error
-	DeleteUser(ctx context.Context, userId uint32) error
+	UpdateProfile(ctx context.Context, user *model.User) error
+	UpdateAvatar(ctx context.Context, userId publicid.ID, avatarUrl string) error
+	UpdatePrivacySettings(ctx context.Context, user *model.User) error
+	DeleteUser(ctx context.Context, userId publicid.ID, deletedAt time.Time) error
+	GetDeletedUserByEmail(ctx context.Context, email string, deletedSince time.Time) (model.User, error)
+	RestoreUser(ctx context.Context, userId publicid.ID, deletedAt time.Time) error
+	GetUsersToPurge(ctx context.Context, deletedBefore time.Time, limit int) ([]model.User, error)
+	PurgeUser(ctx context.Context, userId publicid.ID) ([]string, error)
 }
 
 type userRepositoryImpl struct {
@@ -23,8 +52,8 @@ func NewUserRepository(db infrastructure.GormPostgres) UserRepository {
 	return &userRepositoryImpl{db: db}
 }
 
-func (u *userRepositoryImpl) GetUserById(ctx context.Context, userId uint32) (model.User, error) {
-	db := u.db.GetConnection()
+func (u *userRepositoryImpl) GetUserById(ctx context.Context, userId publicid.ID) (model.User, error) {
+	db := u.db.GetConnection(ctx)
 
 	user := model.User{}
 
@@ -39,7 +68,7 @@ func (u *userRepositoryImpl) GetUserById(ctx context.Context, userId uint32) (mo
 }
 
 func (u *userRepositoryImpl) GetUserByEmail(ctx context.Context, email string) (model.User, error) {
-	db := u.db.GetConnection()
+	db := u.db.GetConnection(ctx)
 
 	user := model.User{}
 
@@ -53,8 +82,94 @@ func (u *userRepositoryImpl) GetUserByEmail(ctx context.Context, email string) (
 	return user, err
 }
 
+func (u *userRepositoryImpl) GetUsersByUsernames(ctx context.Context, usernames []string) ([]model.User, error) {
+	db := u.db.GetConnection(ctx)
+	users := []model.User{}
+
+	lowerUsernames := []string{}
+	for _, username := range usernames {
+		lowerUsernames = append(lowerUsernames, strings.ToLower(username))
+	}
+
+	err := db.
+		WithContext(ctx).
+		Model(&model.User{}).
+		Where("LOWER(username) IN ?", lowerUsernames).
+		Find(&users).
+		Error
+
+	return users, err
+}
+
+func (u *userRepositoryImpl) GetUserByUsername(ctx context.Context, username string) (model.User, error) {
+	db := u.db.GetConnection(ctx)
+
+	user := model.User{}
+
+	err := db.
+		WithContext(ctx).
+		Model(&user).
+		Where("LOWER(username) = ?", strings.ToLower(username)).
+		Find(&user).
+		Error
+
+	return user, err
+}
+
+// GetUsernameSuggestions return the users whose username start with prefix,
+// the exact match and the shortest usernames first.
+func (u *userRepositoryImpl) GetUsernameSuggestions(ctx context.Context, prefix string, limit int) ([]model.UserSuggestion, error) {
+	db := u.db.GetConnection(ctx)
+	users := []model.UserSuggestion{}
+
+	prefix = strings.ToLower(prefix)
+	likeEscaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+	err := db.
+		WithContext(ctx).
+		Model(&model.User{}).
+		Select("id, username").
+		Where("LOWER(username) LIKE ?", likeEscaper.Replace(prefix)+"%").
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "LOWER(username) = ? DESC, LENGTH(username) ASC, LOWER(username) ASC",
+			Vars:               []interface{}{prefix},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Find(&users).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetUserProfile count the photos and the follows of the user, deleted photos
+// and follows of deleted users are not counted.
+func (u *userRepositoryImpl) GetUserProfile(ctx context.Context, userId publicid.ID) (model.UserProfile, error) {
+	db := u.db.GetConnection(ctx)
+
+	profile := model.UserProfile{}
+
+	err := db.
+		WithContext(ctx).
+		Model(&model.User{}).
+		Select("users.id, users.username, users.display_name, users.bio, users.avatar_url, users.website, users.pronouns, users.created_at, "+
+			"users.privacy_show_age, users.privacy_show_bio, users.privacy_show_website, users.privacy_show_pronouns, users.privacy_show_social_medias, "+
+			"(SELECT COUNT(*) FROM photos WHERE photos.user_id = users.id AND photos.deleted_at IS NULL) AS photo_count, "+
+			"(SELECT COUNT(*) FROM follows JOIN users followers ON followers.id = follows.follower_id AND followers.deleted_at IS NULL WHERE follows.followee_id = users.id) AS follower_count, "+
+			"(SELECT COUNT(*) FROM follows JOIN users followees ON followees.id = follows.followee_id AND followees.deleted_at IS NULL WHERE follows.follower_id = users.id) AS following_count").
+		Where("users.id = ?", userId).
+		Find(&profile).
+		Error
+
+	return profile, err
+}
+
 func (u *userRepositoryImpl) CreateUser(ctx context.Context, user *model.User) error {
-	db := u.db.GetConnection()
+	db := u.db.GetConnection(ctx)
 
 	err := db.
 		WithContext(ctx).
@@ -62,31 +177,254 @@ func (u *userRepositoryImpl) CreateUser(ctx context.Context, user *model.User) e
 		Create(&user).
 		Error
 
-	return err
+	return translateUserError(err)
 }
 
 func (u *userRepositoryImpl) EditUser(ctx context.Context, user *model.User) error {
-	db := u.db.GetConnection()
+	db := u.db.GetConnection(ctx)
 
 	err := db.
 		WithContext(ctx).
 		Updates(&user).
 		Error
 
-	return err
+	return translateUserError(err)
 }
 
-func (u *userRepositoryImpl) DeleteUser(ctx context.Context, userId uint32) error {
-	db := u.db.GetConnection()
+func (u *userRepositoryImpl) UpdateProfile(ctx context.Context, user *model.User) error {
+	db := u.db.GetConnection(ctx)
 
-	user := model.User{ID: userId}
+	err := db.
+		WithContext(ctx).
+		Model(&user).
+		Select("display_name", "bio", "website", "pronouns", "updated_at").
+		Updates(&user).
+		Error
+
+	return err
+}
+
+func (u *userRepositoryImpl) UpdateAvatar(ctx context.Context, userId publicid.ID, avatarUrl string) error {
+	db := u.db.GetConnection(ctx)
 
 	err := db.
 		WithContext(ctx).
-		Table("users").
+		Model(&model.User{}).
 		Where("id = ?", userId).
-		Delete(&user).
+		Update("avatar_url", avatarUrl).
+		Error
+
+	return err
+}
+
+func (u *userRepositoryImpl) UpdatePrivacySettings(ctx context.Context, user *model.User) error {
+	db := u.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Model(&user).
+		Select("privacy_show_age", "privacy_show_bio", "privacy_show_website", "privacy_show_pronouns", "privacy_show_social_medias", "updated_at").
+		Updates(&user).
+		Error
+
+	return err
+}
+
+// DeleteUser soft delete the user and the content of the user in one
+// transaction, every row get the same deleted_at so RestoreUser only restore
+// what the account deletion deleted.
+func (u *userRepositoryImpl) DeleteUser(ctx context.Context, userId publicid.ID, deletedAt time.Time) error {
+	db := u.db.GetConnection(ctx)
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range userOwnedTables {
+			query := tx.
+				Table(table).
+				Where("deleted_at IS NULL")
+
+			if table == "comments" {
+				query = query.Where("(user_id = ? OR photo_id IN (SELECT id FROM photos WHERE user_id = ?))", userId, userId)
+			} else {
+				query = query.Where("user_id = ?", userId)
+			}
+
+			err := query.Update("deleted_at", deletedAt).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.
+			Table("users").
+			Where("id = ? AND deleted_at IS NULL", userId).
+			Update("deleted_at", deletedAt).
+			Error
+	})
+}
+
+// GetDeletedUserByEmail return the user of email deleted after deletedSince.
+func (u *userRepositoryImpl) GetDeletedUserByEmail(ctx context.Context, email string, deletedSince time.Time) (model.User, error) {
+	db := u.db.GetConnection(ctx)
+
+	user := model.User{}
+
+	err := db.
+		WithContext(ctx).
+		Unscoped().
+		Model(&user).
+		Where("email = ? AND deleted_at > ?", email, deletedSince).
+		Order("deleted_at DESC").
+		Limit(1).
+		Find(&user).
+		Error
+
+	return user, err
+}
+
+func (u *userRepositoryImpl) RestoreUser(ctx context.Context, userId publicid.ID, deletedAt time.Time) error {
+	db := u.db.GetConnection(ctx)
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Table("users").
+			Where("id = ? AND deleted_at = ?", userId, deletedAt).
+			Update("deleted_at", nil).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		for _, table := range userOwnedTables {
+			query := tx.
+				Table(table).
+				Where("deleted_at = ?", deletedAt)
+
+			if table == "comments" {
+				query = query.Where("(user_id = ? OR photo_id IN (SELECT id FROM photos WHERE user_id = ?))", userId, userId)
+			} else {
+				query = query.Where("user_id = ?", userId)
+			}
+
+			err = query.Update("deleted_at", nil).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (u *userRepositoryImpl) GetUsersToPurge(ctx context.Context, deletedBefore time.Time, limit int) ([]model.User, error) {
+	db := u.db.GetConnection(ctx)
+	users := []model.User{}
+
+	err := db.
+		WithContext(ctx).
+		Unscoped().
+		Model(&model.User{}).
+		Where("deleted_at <= ?", deletedBefore).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&users).
 		Error
 
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// PurgeUser hard delete the user and every row referencing the user or the
+// content of the user, and return the url of the stored files of the user.
+// The comments of the user which have replies of other users are kept empty
+// to keep the threads.
+func (u *userRepositoryImpl) PurgeUser(ctx context.Context, userId publicid.ID) ([]string, error) {
+	db := u.db.GetConnection(ctx)
+	fileUrls := []string{}
+
+	const (
+		userPhotos   = "SELECT id FROM photos WHERE user_id = @user"
+		userComments = "SELECT id FROM comments WHERE user_id = @user OR photo_id IN (" + userPhotos + ")"
+		userAlbums   = "SELECT id FROM albums WHERE user_id = @user"
+		userWebhooks = "SELECT id FROM webhooks WHERE user_id = @user"
+		hasReplies   = "EXISTS (SELECT 1 FROM comments AS replies WHERE replies.parent_id = comments.id)"
+	)
+
+	statements := []string{
+		"DELETE FROM reactions WHERE user_id = @user OR (target_type = 'photo' AND target_id IN (" + userPhotos + ")) OR (target_type = 'comment' AND target_id IN (" + userComments + "))",
+		"DELETE FROM mentions WHERE user_id = @user OR (source_type = 'photo' AND source_id IN (" + userPhotos + ")) OR (source_type = 'comment' AND source_id IN (" + userComments + "))",
+		"DELETE FROM notifications WHERE user_id = @user OR actor_id = @user",
+		"DELETE FROM follows WHERE follower_id = @user OR followee_id = @user",
+		"DELETE FROM photo_tags WHERE photo_id IN (" + userPhotos + ")",
+		"DELETE FROM album_photos WHERE photo_id IN (" + userPhotos + ") OR album_id IN (" + userAlbums + ")",
+		"DELETE FROM albums WHERE user_id = @user",
+		"DELETE FROM comments WHERE photo_id IN (" + userPhotos + ")",
+		"UPDATE comments SET message = '' WHERE user_id = @user AND " + hasReplies,
+		"DELETE FROM comments WHERE user_id = @user AND NOT " + hasReplies,
+		"DELETE FROM social_medias WHERE user_id = @user",
+		"DELETE FROM webhook_deliveries WHERE webhook_id IN (" + userWebhooks + ")",
+		"DELETE FROM webhooks WHERE user_id = @user",
+		"DELETE FROM data_exports WHERE user_id = @user",
+		"DELETE FROM photos WHERE user_id = @user",
+		"DELETE FROM users WHERE id = @user",
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user := model.User{}
+		err := tx.
+			Unscoped().
+			Model(&user).
+			Where("id = ?", userId).
+			Find(&user).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		if user.AvatarUrl != "" {
+			fileUrls = append(fileUrls, user.AvatarUrl)
+		}
+
+		photoUrls := []string{}
+		err = tx.
+			Table("photos").
+			Where("user_id = ?", userId).
+			Pluck("photo_url", &photoUrls).
+			Error
+
+		if err != nil {
+			return err
+		}
+		fileUrls = append(fileUrls, photoUrls...)
+
+		for _, statement := range statements {
+			err = tx.Exec(statement, sql.Named("user", userId)).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fileUrls, nil
+}
+
+// translateUserError turn the violation of the username unique index, which
+// can happen when two requests take the same username at once, into
+// ErrUsernameTaken.
+func translateUserError(err error) error {
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == usernameUniqueIndex {
+		return ErrUsernameTaken
+	}
 	return err
 }
@@ -6,11 +6,13 @@ import (
 	"log"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	mocks "github.com/zikri124/mygram-api/internal/infrastructure/mock"
 	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -37,7 +39,7 @@ func TestGetUser(t *testing.T) {
 		db, mock := newMockGorm()
 
 		postgresMock := mocks.NewGormPostgres(t)
-		postgresMock.On("GetConnection").Return(db)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
 
 		mock.ExpectQuery(regexp.QuoteMeta(`
 			SELECT * FROM "users" WHERE id = $1 AND "users"."deleted_at" IS NULL
@@ -46,14 +48,14 @@ func TestGetUser(t *testing.T) {
 		userRepo := userRepositoryImpl{db: postgresMock}
 		res, err := userRepo.GetUserById(context.Background(), 1)
 		assert.NotNil(t, err)
-		assert.Equal(t, uint32(0), res.ID)
+		assert.Equal(t, publicid.ID(0), res.ID)
 	})
 
 	t.Run("success get user by id", func(t *testing.T) {
 		db, mock := newMockGorm()
 
 		postgresMock := mocks.NewGormPostgres(t)
-		postgresMock.On("GetConnection").Return(db)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
 
 		dobTime, err := helper.ParseStrToTime("2000-12-09")
 		if err != nil {
@@ -71,6 +73,155 @@ func TestGetUser(t *testing.T) {
 		userRepo := userRepositoryImpl{db: postgresMock}
 		res, err := userRepo.GetUserById(context.Background(), 1)
 		assert.Nil(t, err)
-		assert.Equal(t, uint32(1), res.ID)
+		assert.Equal(t, publicid.ID(1), res.ID)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Run("soft delete the user content with the account at the same time", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
+
+		deletedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "photos" SET "deleted_at"=$1 WHERE deleted_at IS NULL AND user_id = $2`)).
+			WithArgs(deletedAt, 1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "comments" SET "deleted_at"=$1 WHERE deleted_at IS NULL AND ((user_id = $2 OR photo_id IN (SELECT id FROM photos WHERE user_id = $3)))`)).
+			WithArgs(deletedAt, 1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "social_medias" SET "deleted_at"=$1 WHERE deleted_at IS NULL AND user_id = $2`)).
+			WithArgs(deletedAt, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "albums" SET "deleted_at"=$1 WHERE deleted_at IS NULL AND user_id = $2`)).
+			WithArgs(deletedAt, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "deleted_at"=$1 WHERE id = $2 AND deleted_at IS NULL`)).
+			WithArgs(deletedAt, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		userRepo := userRepositoryImpl{db: postgresMock}
+		err := userRepo.DeleteUser(context.Background(), 1, deletedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing is deleted when a table fails", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "photos"`)).WillReturnError(errors.New("some error"))
+		mock.ExpectRollback()
+
+		userRepo := userRepositoryImpl{db: postgresMock}
+		err := userRepo.DeleteUser(context.Background(), 1, time.Now())
+		assert.NotNil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRestoreUser(t *testing.T) {
+	t.Run("restore only the content deleted with the account", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
+
+		deletedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "deleted_at"=$1 WHERE id = $2 AND deleted_at = $3`)).
+			WithArgs(nil, 1, deletedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "photos" SET "deleted_at"=$1 WHERE deleted_at = $2 AND user_id = $3`)).
+			WithArgs(nil, deletedAt, 1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "comments" SET "deleted_at"=$1 WHERE deleted_at = $2 AND ((user_id = $3 OR photo_id IN (SELECT id FROM photos WHERE user_id = $4)))`)).
+			WithArgs(nil, deletedAt, 1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "social_medias" SET "deleted_at"=$1 WHERE deleted_at = $2 AND user_id = $3`)).
+			WithArgs(nil, deletedAt, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "albums" SET "deleted_at"=$1 WHERE deleted_at = $2 AND user_id = $3`)).
+			WithArgs(nil, deletedAt, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		userRepo := userRepositoryImpl{db: postgresMock}
+		err := userRepo.RestoreUser(context.Background(), 1, deletedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPurgeUser(t *testing.T) {
+	t.Run("delete the user content in order and keep the comments with replies", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "avatar_url"}).AddRow(1, "/uploads/avatar.jpg"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "photo_url" FROM "photos" WHERE user_id = $1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"photo_url"}).AddRow("/uploads/1.jpg").AddRow("/uploads/2.jpg"))
+
+		statements := []string{
+			"DELETE FROM reactions WHERE user_id = $1",
+			"DELETE FROM mentions WHERE user_id = $1",
+			"DELETE FROM notifications WHERE user_id = $1 OR actor_id = $2",
+			"DELETE FROM follows WHERE follower_id = $1 OR followee_id = $2",
+			"DELETE FROM photo_tags WHERE photo_id IN (SELECT id FROM photos WHERE user_id = $1)",
+			"DELETE FROM album_photos WHERE photo_id IN (SELECT id FROM photos WHERE user_id = $1) OR album_id IN (SELECT id FROM albums WHERE user_id = $2)",
+			"DELETE FROM albums WHERE user_id = $1",
+			"DELETE FROM comments WHERE photo_id IN (SELECT id FROM photos WHERE user_id = $1)",
+			"UPDATE comments SET message = '' WHERE user_id = $1 AND EXISTS (SELECT 1 FROM comments AS replies WHERE replies.parent_id = comments.id)",
+			"DELETE FROM comments WHERE user_id = $1 AND NOT EXISTS (SELECT 1 FROM comments AS replies WHERE replies.parent_id = comments.id)",
+			"DELETE FROM social_medias WHERE user_id = $1",
+			"DELETE FROM webhook_deliveries WHERE webhook_id IN (SELECT id FROM webhooks WHERE user_id = $1)",
+			"DELETE FROM webhooks WHERE user_id = $1",
+			"DELETE FROM data_exports WHERE user_id = $1",
+			"DELETE FROM photos WHERE user_id = $1",
+			"DELETE FROM users WHERE id = $1",
+		}
+		for _, statement := range statements {
+			mock.ExpectExec(regexp.QuoteMeta(statement)).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+
+		userRepo := userRepositoryImpl{db: postgresMock}
+		fileUrls, err := userRepo.PurgeUser(context.Background(), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/uploads/avatar.jpg", "/uploads/1.jpg", "/uploads/2.jpg"}, fileUrls)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing is purged when a statement fails", func(t *testing.T) {
+		db, mock := newMockGorm()
+
+		postgresMock := mocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", context.Background()).Return(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "photo_url" FROM "photos" WHERE user_id = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"photo_url"}))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM reactions")).WillReturnError(errors.New("some error"))
+		mock.ExpectRollback()
+
+		userRepo := userRepositoryImpl{db: postgresMock}
+		fileUrls, err := userRepo.PurgeUser(context.Background(), 1)
+		assert.NotNil(t, err)
+		assert.Nil(t, fileUrls)
+		assert.Nil(t, mock.ExpectationsWereMet())
 	})
 }
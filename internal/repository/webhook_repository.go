@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+var webhookDeliverySortKeys = pagination.SortKeys{
+	pagination.SortNewest: {Column: "created_at", Desc: true, Time: true},
+}
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) error
+	GetWebhooksByUserId(ctx context.Context, userId publicid.ID) ([]model.Webhook, error)
+	GetActiveWebhooksByUserIds(ctx context.Context, userIds []publicid.ID) ([]model.Webhook, error)
+	GetWebhookById(ctx context.Context, webhookId publicid.ID) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) error
+	DeleteWebhook(ctx context.Context, webhookId publicid.ID) error
+	CreateDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error
+	GetDeliveriesByWebhookId(ctx context.Context, webhookId publicid.ID, params pagination.Params) ([]model.WebhookDelivery, error)
+	GetDeliveryById(ctx context.Context, deliveryId publicid.ID) (*model.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+}
+
+type webhookRepositoryImpl struct {
+	db infrastructure.GormPostgres
+}
+
+func NewWebhookRepository(db infrastructure.GormPostgres) WebhookRepository {
+	return &webhookRepositoryImpl{db: db}
+}
+
+func (w *webhookRepositoryImpl) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	db := w.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("webhooks").
+		Create(&webhook).
+		Error
+
+	return err
+}
+
+func (w *webhookRepositoryImpl) GetWebhooksByUserId(ctx context.Context, userId publicid.ID) ([]model.Webhook, error) {
+	db := w.db.GetConnection(ctx)
+	webhooks := []model.Webhook{}
+
+	err := db.
+		WithContext(ctx).
+		Table("webhooks").
+		Where("user_id = ?", userId).
+		Where("deleted_at IS NULL").
+		Order("created_at DESC").
+		Find(&webhooks).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *webhookRepositoryImpl) GetActiveWebhooksByUserIds(ctx context.Context, userIds []publicid.ID) ([]model.Webhook, error) {
+	db := w.db.GetConnection(ctx)
+	webhooks := []model.Webhook{}
+
+	err := db.
+		WithContext(ctx).
+		Table("webhooks").
+		Where("user_id IN ?", userIds).
+		Where("active = ?", true).
+		Where("deleted_at IS NULL").
+		Find(&webhooks).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *webhookRepositoryImpl) GetWebhookById(ctx context.Context, webhookId publicid.ID) (*model.Webhook, error) {
+	db := w.db.GetConnection(ctx)
+	webhook := model.Webhook{}
+
+	err := db.
+		WithContext(ctx).
+		Table("webhooks").
+		Where("id = ?", webhookId).
+		Where("deleted_at IS NULL").
+		Find(&webhook).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (w *webhookRepositoryImpl) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	db := w.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Model(webhook).
+		Select("url", "events", "active", "updated_at").
+		Updates(webhook).
+		Error
+
+	return err
+}
+
+func (w *webhookRepositoryImpl) DeleteWebhook(ctx context.Context, webhookId publicid.ID) error {
+	db := w.db.GetConnection(ctx)
+	webhook := model.Webhook{ID: webhookId}
+
+	err := db.
+		WithContext(ctx).
+		Model(&webhook).
+		Delete(&webhook).
+		Error
+
+	return err
+}
+
+func (w *webhookRepositoryImpl) CreateDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	db := w.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Table("webhook_deliveries").
+		Create(&deliveries).
+		Error
+
+	return err
+}
+
+func (w *webhookRepositoryImpl) GetDeliveriesByWebhookId(ctx context.Context, webhookId publicid.ID, params pagination.Params) ([]model.WebhookDelivery, error) {
+	db := w.db.GetConnection(ctx)
+	deliveries := []model.WebhookDelivery{}
+
+	query := db.
+		WithContext(ctx).
+		Table("webhook_deliveries").
+		Where("webhook_id = ?", webhookId)
+
+	err := pagination.Apply(query, params, webhookDeliverySortKeys, "id").
+		Find(&deliveries).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookRepositoryImpl) GetDeliveryById(ctx context.Context, deliveryId publicid.ID) (*model.WebhookDelivery, error) {
+	db := w.db.GetConnection(ctx)
+	delivery := model.WebhookDelivery{}
+
+	err := db.
+		WithContext(ctx).
+		Table("webhook_deliveries").
+		Where("id = ?", deliveryId).
+		Find(&delivery).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// ClaimDueDeliveries return the pending deliveries due at now and push back
+// their next attempt by lease, so another worker does not pick them up while
+// they are being sent.
+func (w *webhookRepositoryImpl) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error) {
+	db := w.db.GetConnection(ctx)
+	deliveries := []model.WebhookDelivery{}
+
+	err := db.
+		WithContext(ctx).
+		Raw(`UPDATE webhook_deliveries SET next_attempt_at = ?
+			WHERE id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status = ? AND next_attempt_at <= ?
+				ORDER BY next_attempt_at ASC
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *`, now.Add(lease), model.WebhookDeliveryPending, now, limit).
+		Scan(&deliveries).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookRepositoryImpl) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	db := w.db.GetConnection(ctx)
+
+	err := db.
+		WithContext(ctx).
+		Model(delivery).
+		Select("status", "attempts", "response_code", "response_body", "error", "next_attempt_at", "delivered_at", "updated_at").
+		Updates(delivery).
+		Error
+
+	return err
+}
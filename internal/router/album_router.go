@@ -0,0 +1,33 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type AlbumRouter interface {
+	Mount()
+}
+
+type albumRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.AlbumHandler
+	auth    middleware.Authorization
+}
+
+func NewAlbumRouter(v *gin.RouterGroup, handler handler.AlbumHandler, auth middleware.Authorization) AlbumRouter {
+	return &albumRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (a *albumRouterImpl) Mount() {
+	a.v.Use(a.auth.CheckAuth)
+	a.v.POST("", a.handler.PostAlbum)
+	a.v.GET("", a.handler.GetAllAlbumsByUserId)
+	a.v.GET("/:id", a.handler.GetAlbumById)
+	a.v.PUT("/:id", a.handler.UpdateAlbum)
+	a.v.DELETE("/:id", a.handler.DeleteAlbum)
+	a.v.POST("/:id/photos", a.handler.AddPhotoToAlbum)
+	a.v.PUT("/:id/photos", a.handler.ReorderAlbumPhotos)
+	a.v.DELETE("/:id/photos/:photo_id", a.handler.RemovePhotoFromAlbum)
+}
@@ -25,6 +25,7 @@ func (c *commentRouterImpl) Mount() {
 	c.v.POST("", c.handler.PostComment)
 	c.v.GET("", c.handler.GetAllComments)
 	c.v.GET("/:id", c.handler.GetCommentById)
+	c.v.GET("/:id/replies", c.handler.GetReplies)
 	c.v.PUT("/:id", c.handler.UpdateComment)
 	c.v.DELETE("/:id", c.handler.DeleteComment)
 }
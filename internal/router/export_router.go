@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type ExportRouter interface {
+	Mount()
+}
+
+type exportRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.ExportHandler
+	auth    middleware.Authorization
+}
+
+// v must be the /v1 group.
+func NewExportRouter(v *gin.RouterGroup, handler handler.ExportHandler, auth middleware.Authorization) ExportRouter {
+	return &exportRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (e *exportRouterImpl) Mount() {
+	e.v.GET("/exports/:id/download", e.handler.DownloadExport)
+	e.v.Use(e.auth.CheckAuth)
+	e.v.POST("/users/me/export", e.handler.RequestExport)
+	e.v.GET("/users/me/export/:id", e.handler.GetExport)
+}
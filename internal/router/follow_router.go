@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type FollowRouter interface {
+	Mount()
+}
+
+type followRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.FollowHandler
+	auth    middleware.Authorization
+}
+
+// v must be the /v1/users group.
+func NewFollowRouter(v *gin.RouterGroup, handler handler.FollowHandler, auth middleware.Authorization) FollowRouter {
+	return &followRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (f *followRouterImpl) Mount() {
+	f.v.Use(f.auth.CheckAuth)
+	f.v.POST("/:id/follow", f.handler.FollowUser)
+	f.v.DELETE("/:id/follow", f.handler.UnfollowUser)
+}
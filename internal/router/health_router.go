@@ -0,0 +1,24 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+)
+
+type HealthRouter interface {
+	Mount()
+}
+
+type healthRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.HealthHandler
+}
+
+func NewHealthRouter(v *gin.RouterGroup, handler handler.HealthHandler) HealthRouter {
+	return &healthRouterImpl{v: v, handler: handler}
+}
+
+func (h *healthRouterImpl) Mount() {
+	h.v.GET("/healthz", h.handler.Healthz)
+	h.v.GET("/readyz", h.handler.Readyz)
+}
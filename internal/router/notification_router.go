@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type NotificationRouter interface {
+	Mount()
+}
+
+type notificationRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.NotificationHandler
+	auth    middleware.Authorization
+}
+
+func NewNotificationRouter(v *gin.RouterGroup, handler handler.NotificationHandler, auth middleware.Authorization) NotificationRouter {
+	return &notificationRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (n *notificationRouterImpl) Mount() {
+	n.v.Use(n.auth.CheckAuth)
+	n.v.GET("", n.handler.GetNotifications)
+	n.v.POST("/read", n.handler.MarkNotificationsRead)
+	n.v.POST("/read-all", n.handler.MarkAllNotificationsRead)
+}
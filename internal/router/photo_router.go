@@ -23,6 +23,7 @@ func NewPhotoRouter(v *gin.RouterGroup, handler handler.PhotoHandler, auth middl
 func (p *photoRouterImpl) Mount() {
 	p.v.Use(p.auth.CheckAuth)
 	p.v.POST("", p.handler.PostPhoto)
+	p.v.POST("/upload", p.handler.UploadPhoto)
 	p.v.GET("", p.handler.GetAllPhotosByUserId)
 	p.v.GET("/:id", p.handler.GetPhotoById)
 	p.v.PUT("/:id", p.handler.UpdatePhoto)
@@ -0,0 +1,32 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type ReactionRouter interface {
+	Mount()
+}
+
+type reactionRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.ReactionHandler
+	auth    middleware.Authorization
+}
+
+// NewReactionRouter mount the reactions under the comments and photos routes,
+// v must be the /v1 group.
+func NewReactionRouter(v *gin.RouterGroup, handler handler.ReactionHandler, auth middleware.Authorization) ReactionRouter {
+	return &reactionRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (r *reactionRouterImpl) Mount() {
+	r.v.Use(r.auth.CheckAuth)
+	r.v.PUT("/comments/:id/reactions", r.handler.ReactToComment)
+	r.v.DELETE("/comments/:id/reactions", r.handler.RemoveCommentReaction)
+	r.v.GET("/photos/:id/reactions", r.handler.GetPhotoReactions)
+	r.v.PUT("/photos/:id/reactions", r.handler.ReactToPhoto)
+	r.v.DELETE("/photos/:id/reactions", r.handler.RemovePhotoReaction)
+}
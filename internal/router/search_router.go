@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type SearchRouter interface {
+	Mount()
+}
+
+type searchRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.SearchHandler
+	auth    middleware.Authorization
+}
+
+func NewSearchRouter(v *gin.RouterGroup, handler handler.SearchHandler, auth middleware.Authorization) SearchRouter {
+	return &searchRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (s *searchRouterImpl) Mount() {
+	s.v.Use(s.auth.CheckAuth)
+	s.v.GET("", s.handler.Search)
+}
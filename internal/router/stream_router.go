@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type StreamRouter interface {
+	Mount()
+}
+
+type streamRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.StreamHandler
+	auth    middleware.Authorization
+}
+
+func NewStreamRouter(v *gin.RouterGroup, handler handler.StreamHandler, auth middleware.Authorization) StreamRouter {
+	return &streamRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (s *streamRouterImpl) Mount() {
+	s.v.Use(s.auth.CheckAuth)
+	s.v.GET("", s.handler.Stream)
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type TagRouter interface {
+	Mount()
+}
+
+type tagRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.TagHandler
+	auth    middleware.Authorization
+}
+
+func NewTagRouter(v *gin.RouterGroup, handler handler.TagHandler, auth middleware.Authorization) TagRouter {
+	return &tagRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (t *tagRouterImpl) Mount() {
+	t.v.Use(t.auth.CheckAuth)
+	t.v.GET("/trending", t.handler.GetTrendingTags)
+	t.v.GET("/:tag/photos", t.handler.GetPhotosByTag)
+}
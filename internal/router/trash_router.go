@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type TrashRouter interface {
+	Mount()
+}
+
+type trashRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.TrashHandler
+	auth    middleware.Authorization
+}
+
+func NewTrashRouter(v *gin.RouterGroup, handler handler.TrashHandler, auth middleware.Authorization) TrashRouter {
+	return &trashRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (t *trashRouterImpl) Mount() {
+	t.v.Use(t.auth.CheckAuth)
+	t.v.GET("", t.handler.GetTrash)
+	t.v.POST("/photos/:id/restore", t.handler.RestorePhoto)
+	t.v.POST("/comments/:id/restore", t.handler.RestoreComment)
+	t.v.POST("/social_medias/:id/restore", t.handler.RestoreSocialMedia)
+}
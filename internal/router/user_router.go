@@ -11,20 +11,33 @@ type UserRouter interface {
 }
 
 type userRouterImpl struct {
-	v       *gin.RouterGroup
-	handler handler.UserHandler
-	auth    middleware.Authorization
+	v            *gin.RouterGroup
+	handler      handler.UserHandler
+	auth         middleware.Authorization
+	registration bool
 }
 
-func NewUserRouter(v *gin.RouterGroup, handler handler.UserHandler, auth middleware.Authorization) UserRouter {
-	return &userRouterImpl{v: v, handler: handler, auth: auth}
+// NewUserRouter mount /register only when registration is enabled.
+func NewUserRouter(v *gin.RouterGroup, handler handler.UserHandler, auth middleware.Authorization, registration bool) UserRouter {
+	return &userRouterImpl{v: v, handler: handler, auth: auth, registration: registration}
 }
 
 func (u *userRouterImpl) Mount() {
-	u.v.GET("/:id", u.handler.GetUserById)
-	u.v.POST("/register", u.handler.UserRegister)
+	u.v.GET("/:id", u.auth.OptionalAuth, u.handler.GetUserById)
+	u.v.GET("/:id/profile", u.auth.OptionalAuth, u.handler.GetUserProfile)
+	u.v.GET("/by-username/:username", u.auth.OptionalAuth, u.handler.GetUserByUsername)
+	if u.registration {
+		u.v.POST("/register", u.handler.UserRegister)
+	}
 	u.v.POST("/login", u.handler.UserLogin)
 	u.v.Use(u.auth.CheckAuth)
+	u.v.GET("/autocomplete", u.handler.UsernameAutocomplete)
+	u.v.GET("/me", u.handler.GetMe)
+	u.v.GET("/me/privacy", u.handler.GetPrivacySettings)
+	u.v.PATCH("/me/privacy", u.handler.UpdatePrivacySettings)
+	u.v.PATCH("/me/profile", u.handler.UpdateProfile)
+	u.v.PUT("/me/avatar", u.handler.UpdateAvatar)
+	u.v.DELETE("/me/avatar", u.handler.RemoveAvatar)
 	u.v.PUT("/:id", u.handler.UserEdit)
 	u.v.DELETE("", u.handler.UserDelete)
 }
@@ -0,0 +1,32 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/internal/handler"
+	"github.com/zikri124/mygram-api/internal/middleware"
+)
+
+type WebhookRouter interface {
+	Mount()
+}
+
+type webhookRouterImpl struct {
+	v       *gin.RouterGroup
+	handler handler.WebhookHandler
+	auth    middleware.Authorization
+}
+
+func NewWebhookRouter(v *gin.RouterGroup, handler handler.WebhookHandler, auth middleware.Authorization) WebhookRouter {
+	return &webhookRouterImpl{v: v, handler: handler, auth: auth}
+}
+
+func (w *webhookRouterImpl) Mount() {
+	w.v.Use(w.auth.CheckAuth)
+	w.v.POST("", w.handler.PostWebhook)
+	w.v.GET("", w.handler.GetWebhooks)
+	w.v.GET("/:id", w.handler.GetWebhookById)
+	w.v.PUT("/:id", w.handler.UpdateWebhook)
+	w.v.DELETE("/:id", w.handler.DeleteWebhook)
+	w.v.GET("/:id/deliveries", w.handler.GetDeliveries)
+	w.v.POST("/:id/deliveries/:delivery_id/redeliver", w.handler.Redeliver)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+var ErrPhotoAlreadyInAlbum = repository.ErrPhotoAlreadyInAlbum
+
+type AlbumService interface {
+	PostAlbum(ctx context.Context, userId publicid.ID, newAlbum model.CreateAlbum) (*model.AlbumRes, error)
+	GetAllAlbumsByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) (*pagination.Page, error)
+	GetAlbumById(ctx context.Context, albumId publicid.ID) (*model.AlbumView, error)
+	GetAlbumDetail(ctx context.Context, albumId publicid.ID) (*model.AlbumDetail, error)
+	GetAlbumPhotos(ctx context.Context, albumId publicid.ID) ([]model.AlbumPhotoView, error)
+	UpdateAlbum(ctx context.Context, album model.Album) (*model.AlbumRes, error)
+	DeleteAlbum(ctx context.Context, albumId publicid.ID) error
+	AddPhotoToAlbum(ctx context.Context, albumId publicid.ID, photoId publicid.ID) error
+	RemovePhotoFromAlbum(ctx context.Context, albumId publicid.ID, photoId publicid.ID) error
+	ReorderAlbumPhotos(ctx context.Context, albumId publicid.ID, photoIds []publicid.ID) error
+}
+
+type albumServiceImpl struct {
+	repo repository.AlbumRepository
+}
+
+func NewAlbumService(repo repository.AlbumRepository) AlbumService {
+	return &albumServiceImpl{repo: repo}
+}
+
+func (a *albumServiceImpl) PostAlbum(ctx context.Context, userId publicid.ID, newAlbum model.CreateAlbum) (*model.AlbumRes, error) {
+	album := model.Album{}
+	album.UserId = userId
+	album.Title = newAlbum.Title
+	album.Description = newAlbum.Description
+	album.CoverPhotoId = newAlbum.CoverPhotoId
+
+	err := a.repo.CreateAlbum(ctx, &album)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAlbumRes(album), nil
+}
+
+func (a *albumServiceImpl) GetAllAlbumsByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) (*pagination.Page, error) {
+	albums, err := a.repo.GetAllAlbumsByUserId(ctx, userId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.NewPage(albums, params, model.AlbumView.SortKey)
+
+	return &page, nil
+}
+
+func (a *albumServiceImpl) GetAlbumById(ctx context.Context, albumId publicid.ID) (*model.AlbumView, error) {
+	album, err := a.repo.GetAlbumById(ctx, albumId)
+	if err != nil {
+		return nil, err
+	}
+
+	return album, nil
+}
+
+func (a *albumServiceImpl) GetAlbumDetail(ctx context.Context, albumId publicid.ID) (*model.AlbumDetail, error) {
+	album, err := a.repo.GetAlbumById(ctx, albumId)
+	if err != nil {
+		return nil, err
+	}
+
+	if album.ID == 0 {
+		return &model.AlbumDetail{}, nil
+	}
+
+	photos, err := a.repo.GetAlbumPhotos(ctx, albumId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AlbumDetail{AlbumView: *album, Photos: photos}, nil
+}
+
+func (a *albumServiceImpl) GetAlbumPhotos(ctx context.Context, albumId publicid.ID) ([]model.AlbumPhotoView, error) {
+	return a.repo.GetAlbumPhotos(ctx, albumId)
+}
+
+func (a *albumServiceImpl) UpdateAlbum(ctx context.Context, album model.Album) (*model.AlbumRes, error) {
+	album.UpdatedAt = time.Now()
+
+	err := a.repo.UpdateAlbum(ctx, &album)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAlbumRes(album), nil
+}
+
+func (a *albumServiceImpl) DeleteAlbum(ctx context.Context, albumId publicid.ID) error {
+	return a.repo.DeleteAlbum(ctx, albumId)
+}
+
+// AddPhotoToAlbum place the photo at the end of the album, it return
+// ErrPhotoAlreadyInAlbum when the photo is already in it.
+func (a *albumServiceImpl) AddPhotoToAlbum(ctx context.Context, albumId publicid.ID, photoId publicid.ID) error {
+	return a.repo.AddPhotoToAlbum(ctx, albumId, photoId)
+}
+
+func (a *albumServiceImpl) RemovePhotoFromAlbum(ctx context.Context, albumId publicid.ID, photoId publicid.ID) error {
+	return a.repo.RemovePhotoFromAlbum(ctx, albumId, photoId)
+}
+
+func (a *albumServiceImpl) ReorderAlbumPhotos(ctx context.Context, albumId publicid.ID, photoIds []publicid.ID) error {
+	return a.repo.ReorderAlbumPhotos(ctx, albumId, photoIds)
+}
+
+func toAlbumRes(album model.Album) *model.AlbumRes {
+	albumRes := model.AlbumRes{}
+	albumRes.ID = album.ID
+	albumRes.UserId = album.UserId
+	albumRes.Title = album.Title
+	albumRes.Description = album.Description
+	albumRes.CoverPhotoId = album.CoverPhotoId
+	albumRes.CreatedAt = album.CreatedAt
+	albumRes.UpdatedAt = album.UpdatedAt
+
+	return &albumRes
+}
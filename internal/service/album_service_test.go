@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zikri124/mygram-api/internal/model"
+	repoMocks "github.com/zikri124/mygram-api/internal/repository/mocks"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+func TestAlbumService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create album of the user", func(t *testing.T) {
+		repo := repoMocks.NewAlbumRepository(t)
+		repo.On("CreateAlbum", ctx, mock.MatchedBy(func(album *model.Album) bool {
+			return album.UserId == 1 && album.Title == "Holidays"
+		})).Return(func(ctx context.Context, album *model.Album) error {
+			album.ID = 10
+			return nil
+		})
+
+		albumService := albumServiceImpl{repo: repo}
+		album, err := albumService.PostAlbum(ctx, 1, model.CreateAlbum{Title: "Holidays"})
+		assert.Nil(t, err)
+		assert.Equal(t, publicid.ID(10), album.ID)
+		assert.Equal(t, publicid.ID(1), album.UserId)
+	})
+
+	t.Run("photo already in the album", func(t *testing.T) {
+		repo := repoMocks.NewAlbumRepository(t)
+		repo.On("AddPhotoToAlbum", ctx, publicid.ID(10), publicid.ID(20)).Return(ErrPhotoAlreadyInAlbum)
+
+		albumService := albumServiceImpl{repo: repo}
+		err := albumService.AddPhotoToAlbum(ctx, 10, 20)
+		assert.ErrorIs(t, err, ErrPhotoAlreadyInAlbum)
+	})
+
+	t.Run("reorder the photos", func(t *testing.T) {
+		repo := repoMocks.NewAlbumRepository(t)
+		repo.On("ReorderAlbumPhotos", ctx, publicid.ID(10), []publicid.ID{21, 20}).Return(nil)
+
+		albumService := albumServiceImpl{repo: repo}
+		err := albumService.ReorderAlbumPhotos(ctx, 10, []publicid.ID{21, 20})
+		assert.Nil(t, err)
+	})
+}
@@ -2,34 +2,113 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
+	"github.com/zikri124/mygram-api/internal/infrastructure"
 	"github.com/zikri124/mygram-api/internal/model"
 	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 )
 
+var ErrCommentMaxDepth = errors.New("maximum reply depth reached")
+
+type CommentConfig struct {
+	// MaxDepth is the deepest reply level accepted, 0 disable the replies.
+	MaxDepth int `yaml:"max_depth" env:"COMMENT_MAX_DEPTH"`
+}
+
 type CommentService interface {
-	PostComment(ctx context.Context, userId uint32, newComment model.CreateComment) (*model.CreateCommentRes, error)
-	GetAllCommentsByPhotoId(ctx context.Context, photoId uint32) ([]model.CommentView, error)
-	GetCommentById(ctx context.Context, commentId uint32) (*model.CommentView, error)
+	PostComment(ctx context.Context, userId publicid.ID, newComment model.CreateComment) (*model.CreateCommentRes, error)
+	GetAllCommentsByPhotoId(ctx context.Context, photoId publicid.ID, params pagination.Params, viewerId publicid.ID) (*pagination.Page, error)
+	GetRepliesByCommentId(ctx context.Context, commentId publicid.ID, params pagination.Params, viewerId publicid.ID) (*model.CommentRepliesRes, error)
+	GetCommentById(ctx context.Context, commentId publicid.ID) (*model.CommentView, error)
 	UpdateComment(ctx context.Context, comment model.Comment) (*model.UpdateCommentRes, error)
-	DeleteComment(ctx context.Context, commentId uint32) error
+	DeleteComment(ctx context.Context, commentId publicid.ID) error
 }
 
 type commentServiceImpl struct {
-	repo repository.CommentRepository
+	repo        repository.CommentRepository
+	photoRepo   repository.PhotoRepository
+	mentionSvc  MentionService
+	notifSvc    NotificationService
+	hub         infrastructure.EventHub
+	webhookSvc  WebhookService
+	reactionSvc ReactionService
+	txManager   infrastructure.TxManager
+	maxDepth    int
 }
 
-func NewCommentService(repo repository.CommentRepository) CommentService {
-	return &commentServiceImpl{repo: repo}
+func NewCommentService(repo repository.CommentRepository, photoRepo repository.PhotoRepository, mentionSvc MentionService, notifSvc NotificationService, hub infrastructure.EventHub, webhookSvc WebhookService, reactionSvc ReactionService, txManager infrastructure.TxManager, config CommentConfig) CommentService {
+	return &commentServiceImpl{repo: repo, photoRepo: photoRepo, mentionSvc: mentionSvc, notifSvc: notifSvc, hub: hub, webhookSvc: webhookSvc, reactionSvc: reactionSvc, txManager: txManager, maxDepth: config.MaxDepth}
 }
 
-func (c *commentServiceImpl) PostComment(ctx context.Context, userId uint32, newComment model.CreateComment) (*model.CreateCommentRes, error) {
+// PostComment create a comment on a photo, or a reply when ParentId is set.
+// Replies deeper than CommentConfig.MaxDepth are refused with
+// ErrCommentMaxDepth.
+func (c *commentServiceImpl) PostComment(ctx context.Context, userId publicid.ID, newComment model.CreateComment) (*model.CreateCommentRes, error) {
 	comment := model.Comment{}
 	comment.UserId = userId
 	comment.Message = newComment.Message
 	comment.PhotoId = newComment.PhotoId
 
-	err := c.repo.CreateComment(ctx, &comment)
+	var parent *model.CommentView
+	if newComment.ParentId != nil {
+		var err error
+		parent, err = c.repo.GetCommentById(ctx, *newComment.ParentId)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent.Depth+1 > c.maxDepth {
+			return nil, ErrCommentMaxDepth
+		}
+
+		comment.ParentId = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+
+	var mentions []model.MentionView
+	var photo *model.PhotoView
+	err := c.txManager.WithTx(ctx, func(ctx context.Context) error {
+		err := c.repo.CreateComment(ctx, &comment)
+		if err != nil {
+			return err
+		}
+
+		mentions, err = c.mentionSvc.SyncMentions(ctx, model.MentionSourceComment, comment.ID, comment.UserId, comment.Message)
+		if err != nil {
+			return err
+		}
+
+		photo, err = c.photoRepo.GetPhotoById(ctx, comment.PhotoId)
+		if err != nil {
+			return err
+		}
+
+		notifications := []model.Notification{}
+		if parent != nil {
+			notifications = append(notifications, model.Notification{
+				UserId:     parent.UserId,
+				ActorId:    comment.UserId,
+				Type:       model.NotificationTypeReply,
+				EntityType: "comment",
+				EntityId:   parent.ID,
+			})
+		}
+		if parent == nil || parent.UserId != photo.UserId {
+			notifications = append(notifications, model.Notification{
+				UserId:     photo.UserId,
+				ActorId:    comment.UserId,
+				Type:       model.NotificationTypeComment,
+				EntityType: "photo",
+				EntityId:   comment.PhotoId,
+			})
+		}
+
+		return c.notifSvc.Notify(ctx, notifications...)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -38,22 +117,73 @@ func (c *commentServiceImpl) PostComment(ctx context.Context, userId uint32, new
 	commentRes.ID = comment.ID
 	commentRes.Message = comment.Message
 	commentRes.PhotoId = comment.PhotoId
+	commentRes.ParentId = comment.ParentId
+	commentRes.Depth = comment.Depth
 	commentRes.UserId = comment.UserId
+	commentRes.Mentions = mentions
 	commentRes.CreatedAt = comment.CreatedAt
 
+	err = c.hub.Publish(ctx, infrastructure.PhotoTopic(comment.PhotoId), "comment.created", commentRes)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot publish comment event", "error", err)
+	}
+
+	err = c.webhookSvc.Dispatch(ctx, model.WebhookEventCommentCreated, []publicid.ID{comment.UserId, photo.UserId}, commentRes)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot dispatch comment webhook", "error", err)
+	}
+
 	return &commentRes, nil
 }
 
-func (c *commentServiceImpl) GetAllCommentsByPhotoId(ctx context.Context, photoId uint32) ([]model.CommentView, error) {
-	comments, err := c.repo.GetAllCommentsByPhotoId(ctx, photoId)
+func (c *commentServiceImpl) GetAllCommentsByPhotoId(ctx context.Context, photoId publicid.ID, params pagination.Params, viewerId publicid.ID) (*pagination.Page, error) {
+	comments, err := c.repo.GetAllCommentsByPhotoId(ctx, photoId, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return comments, nil
+	comments, nextCursor := pagination.Trim(comments, params, model.CommentView.SortKey)
+
+	err = c.attachReactions(ctx, comments, viewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{Data: hideDeletedComments(comments), NextCursor: nextCursor, Limit: params.Limit}
+
+	return &page, nil
 }
 
-func (c *commentServiceImpl) GetCommentById(ctx context.Context, commentId uint32) (*model.CommentView, error) {
+// GetRepliesByCommentId return a page of the direct replies of a comment, the
+// reply count is counted apart so it is right for a deleted comment kept as a
+// placeholder.
+func (c *commentServiceImpl) GetRepliesByCommentId(ctx context.Context, commentId publicid.ID, params pagination.Params, viewerId publicid.ID) (*model.CommentRepliesRes, error) {
+	replyCount, err := c.repo.CountReplies(ctx, commentId)
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := c.repo.GetRepliesByCommentId(ctx, commentId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	replies, nextCursor := pagination.Trim(replies, params, model.CommentView.SortKey)
+
+	err = c.attachReactions(ctx, replies, viewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	repliesRes := model.CommentRepliesRes{}
+	repliesRes.ParentId = commentId
+	repliesRes.ReplyCount = replyCount
+	repliesRes.Page = pagination.Page{Data: hideDeletedComments(replies), NextCursor: nextCursor, Limit: params.Limit}
+
+	return &repliesRes, nil
+}
+
+func (c *commentServiceImpl) GetCommentById(ctx context.Context, commentId publicid.ID) (*model.CommentView, error) {
 	comment, err := c.repo.GetCommentById(ctx, commentId)
 	if err != nil {
 		return nil, err
@@ -63,8 +193,16 @@ func (c *commentServiceImpl) GetCommentById(ctx context.Context, commentId uint3
 }
 
 func (c *commentServiceImpl) UpdateComment(ctx context.Context, comment model.Comment) (*model.UpdateCommentRes, error) {
-	err := c.repo.UpdateComment(ctx, &comment)
+	var mentions []model.MentionView
+	err := c.txManager.WithTx(ctx, func(ctx context.Context) error {
+		err := c.repo.UpdateComment(ctx, &comment)
+		if err != nil {
+			return err
+		}
 
+		mentions, err = c.mentionSvc.SyncMentions(ctx, model.MentionSourceComment, comment.ID, comment.UserId, comment.Message)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -74,13 +212,54 @@ func (c *commentServiceImpl) UpdateComment(ctx context.Context, comment model.Co
 	commentRes.Message = comment.Message
 	commentRes.PhotoId = comment.PhotoId
 	commentRes.UserId = comment.UserId
+	commentRes.Mentions = mentions
 	commentRes.UpdatedAt = comment.UpdatedAt
 
 	return &commentRes, nil
 }
 
-func (c *commentServiceImpl) DeleteComment(ctx context.Context, commentId uint32) error {
+func (c *commentServiceImpl) DeleteComment(ctx context.Context, commentId publicid.ID) error {
 	err := c.repo.DeleteComment(ctx, commentId)
 
 	return err
 }
+
+// attachReactions set the reaction counts of the comments and the reaction of
+// viewerId on each of them.
+func (c *commentServiceImpl) attachReactions(ctx context.Context, comments []model.CommentView, viewerId publicid.ID) error {
+	commentIds := []publicid.ID{}
+	for _, comment := range comments {
+		commentIds = append(commentIds, comment.ID)
+	}
+
+	summaries, err := c.reactionSvc.GetReactionSummaries(ctx, model.ReactionTargetComment, commentIds, viewerId)
+	if err != nil {
+		return err
+	}
+
+	for i := range comments {
+		summary := summaries[comments[i].ID]
+		comments[i].Reactions = summary.Reactions
+		comments[i].MyReaction = summary.MyReaction
+	}
+
+	return nil
+}
+
+// hideDeletedComments replace the content of the deleted comments kept in a
+// thread by a placeholder.
+func hideDeletedComments(comments []model.CommentView) []model.CommentView {
+	for i := range comments {
+		if comments[i].DeletedAt == nil {
+			continue
+		}
+
+		comments[i].IsDeleted = true
+		comments[i].Message = model.DeletedCommentMessage
+		comments[i].UserId = 0
+		comments[i].User = model.UserItem{}
+		comments[i].Mentions = []model.MentionView{}
+	}
+
+	return comments
+}
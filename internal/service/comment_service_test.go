@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	infraMocks "github.com/zikri124/mygram-api/internal/infrastructure/mock"
+	"github.com/zikri124/mygram-api/internal/model"
+	repoMocks "github.com/zikri124/mygram-api/internal/repository/mocks"
+	"github.com/zikri124/mygram-api/internal/service/mocks"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+type commentServiceMocks struct {
+	repo        *repoMocks.CommentRepository
+	photoRepo   *repoMocks.PhotoRepository
+	mentionSvc  *mocks.MentionService
+	notifSvc    *mocks.NotificationService
+	hub         *infraMocks.EventHub
+	webhookSvc  *mocks.WebhookService
+	reactionSvc *mocks.ReactionService
+	txManager   *infraMocks.TxManager
+}
+
+func newCommentService(t *testing.T, maxDepth int) (*commentServiceImpl, commentServiceMocks) {
+	m := commentServiceMocks{
+		repo:        repoMocks.NewCommentRepository(t),
+		photoRepo:   repoMocks.NewPhotoRepository(t),
+		mentionSvc:  mocks.NewMentionService(t),
+		notifSvc:    mocks.NewNotificationService(t),
+		hub:         infraMocks.NewEventHub(t),
+		webhookSvc:  mocks.NewWebhookService(t),
+		reactionSvc: mocks.NewReactionService(t),
+		txManager:   infraMocks.NewTxManager(t),
+	}
+
+	commentService := &commentServiceImpl{
+		repo:        m.repo,
+		photoRepo:   m.photoRepo,
+		mentionSvc:  m.mentionSvc,
+		notifSvc:    m.notifSvc,
+		hub:         m.hub,
+		webhookSvc:  m.webhookSvc,
+		reactionSvc: m.reactionSvc,
+		txManager:   m.txManager,
+		maxDepth:    maxDepth,
+	}
+
+	return commentService, m
+}
+
+func TestPostComment(t *testing.T) {
+	ctx := context.Background()
+	parentId := publicid.ID(20)
+
+	t.Run("reply is one level below its parent and notify the parent author", func(t *testing.T) {
+		commentService, m := newCommentService(t, 3)
+
+		m.repo.On("GetCommentById", ctx, parentId).Return(&model.CommentView{ID: parentId, UserId: 2, PhotoId: 100, Depth: 1}, nil)
+		m.txManager.On("WithTx", ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+		m.repo.On("CreateComment", ctx, mock.MatchedBy(func(comment *model.Comment) bool {
+			return *comment.ParentId == parentId && comment.Depth == 2
+		})).Return(func(ctx context.Context, comment *model.Comment) error {
+			comment.ID = 21
+			return nil
+		})
+		m.mentionSvc.On("SyncMentions", ctx, model.MentionSourceComment, publicid.ID(21), publicid.ID(1), "agreed").Return([]model.MentionView{}, nil)
+		m.photoRepo.On("GetPhotoById", ctx, publicid.ID(100)).Return(&model.PhotoView{ID: 100, UserId: 3}, nil)
+		m.notifSvc.On("Notify", ctx,
+			model.Notification{UserId: 2, ActorId: 1, Type: model.NotificationTypeReply, EntityType: "comment", EntityId: parentId},
+			model.Notification{UserId: 3, ActorId: 1, Type: model.NotificationTypeComment, EntityType: "photo", EntityId: 100},
+		).Return(nil)
+		m.hub.On("Publish", ctx, mock.Anything, "comment.created", mock.Anything).Return(nil)
+		m.webhookSvc.On("Dispatch", ctx, model.WebhookEventCommentCreated, []publicid.ID{1, 3}, mock.Anything).Return(nil)
+
+		comment, err := commentService.PostComment(ctx, 1, model.CreateComment{PhotoId: 100, ParentId: &parentId, Message: "agreed"})
+		assert.Nil(t, err)
+		assert.Equal(t, parentId, *comment.ParentId)
+		assert.Equal(t, 2, comment.Depth)
+	})
+
+	t.Run("reply below the maximum depth is refused", func(t *testing.T) {
+		commentService, m := newCommentService(t, 3)
+
+		m.repo.On("GetCommentById", ctx, parentId).Return(&model.CommentView{ID: parentId, PhotoId: 100, Depth: 3}, nil)
+
+		comment, err := commentService.PostComment(ctx, 1, model.CreateComment{PhotoId: 100, ParentId: &parentId, Message: "too deep"})
+		assert.ErrorIs(t, err, ErrCommentMaxDepth)
+		assert.Nil(t, comment)
+	})
+
+	t.Run("maximum depth of 0 disable the replies", func(t *testing.T) {
+		commentService, m := newCommentService(t, 0)
+
+		m.repo.On("GetCommentById", ctx, parentId).Return(&model.CommentView{ID: parentId, PhotoId: 100}, nil)
+
+		_, err := commentService.PostComment(ctx, 1, model.CreateComment{PhotoId: 100, ParentId: &parentId, Message: "reply"})
+		assert.ErrorIs(t, err, ErrCommentMaxDepth)
+	})
+}
+
+func TestGetRepliesByCommentId(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Now()
+	params := pagination.Params{Limit: 10, Sort: pagination.SortOldest}
+
+	t.Run("deleted parent keep its reply count and deleted replies are placeholders", func(t *testing.T) {
+		commentService, m := newCommentService(t, 3)
+
+		m.repo.On("CountReplies", ctx, publicid.ID(20)).Return(int64(2), nil)
+		m.repo.On("GetRepliesByCommentId", ctx, publicid.ID(20), params).Return([]model.CommentView{
+			{ID: 21, UserId: 2, Message: "first", User: model.UserItem{ID: 2, Username: "bob"}},
+			{ID: 22, UserId: 3, Message: "removed", ReplyCount: 1, DeletedAt: &deletedAt, User: model.UserItem{ID: 3, Username: "carol"}},
+		}, nil)
+		m.reactionSvc.On("GetReactionSummaries", ctx, model.ReactionTargetComment, []publicid.ID{21, 22}, publicid.ID(1)).Return(map[publicid.ID]model.ReactionSummary{}, nil)
+
+		replies, err := commentService.GetRepliesByCommentId(ctx, 20, params, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), replies.ReplyCount)
+
+		data := replies.Data.([]model.CommentView)
+		assert.False(t, data[0].IsDeleted)
+		assert.Equal(t, "first", data[0].Message)
+		assert.True(t, data[1].IsDeleted)
+		assert.Equal(t, model.DeletedCommentMessage, data[1].Message)
+		assert.Equal(t, publicid.ID(0), data[1].UserId)
+		assert.Equal(t, model.UserItem{}, data[1].User)
+		assert.Equal(t, int64(1), data[1].ReplyCount)
+	})
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+const (
+	exportMaxAttempts    = 3
+	exportLinkTTL        = 7 * 24 * time.Hour
+	exportWorkerInterval = 10 * time.Second
+	exportClaimLease     = 15 * time.Minute
+	exportClaimBatchSize = 2
+)
+
+var (
+	ErrExportNotFound = errors.New("export did not exist")
+	ErrExportExpired  = errors.New("export download link has expired")
+)
+
+type ExportService interface {
+	RequestExport(ctx context.Context, userId publicid.ID) (*model.DataExportRes, error)
+	GetExportById(ctx context.Context, exportId publicid.ID) (*model.DataExport, error)
+	GetExportFile(ctx context.Context, exportId publicid.ID, token string) (string, error)
+	RunWorker(ctx context.Context, stop <-chan struct{})
+}
+
+type exportServiceImpl struct {
+	repo      repository.ExportRepository
+	userRepo  repository.UserRepository
+	storage   infrastructure.FileStorage
+	exportDir string
+}
+
+func NewExportService(repo repository.ExportRepository, userRepo repository.UserRepository, storage infrastructure.FileStorage, exportDir string) ExportService {
+	return &exportServiceImpl{repo: repo, userRepo: userRepo, storage: storage, exportDir: exportDir}
+}
+
+// RequestExport queue a new export, the pending export of the user is
+// returned instead when there is one.
+func (e *exportServiceImpl) RequestExport(ctx context.Context, userId publicid.ID) (*model.DataExportRes, error) {
+	export, err := e.repo.GetPendingExportByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if export.ID == 0 {
+		export = &model.DataExport{UserId: userId, Status: model.DataExportPending}
+		err = e.repo.CreateExport(ctx, export)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ToDataExportRes(*export), nil
+}
+
+func (e *exportServiceImpl) GetExportById(ctx context.Context, exportId publicid.ID) (*model.DataExport, error) {
+	return e.repo.GetExportById(ctx, exportId)
+}
+
+// GetExportFile return the path of the archive when token is the download
+// token of the export.
+func (e *exportServiceImpl) GetExportFile(ctx context.Context, exportId publicid.ID, token string) (string, error) {
+	export, err := e.repo.GetExportById(ctx, exportId)
+	if err != nil {
+		return "", err
+	}
+
+	if export.ID == 0 || export.DownloadToken == "" || subtle.ConstantTimeCompare([]byte(export.DownloadToken), []byte(token)) != 1 {
+		return "", ErrExportNotFound
+	}
+
+	if export.Status == model.DataExportExpired || (export.ExpiresAt != nil && export.ExpiresAt.Before(time.Now())) {
+		return "", ErrExportExpired
+	}
+
+	if export.Status != model.DataExportReady {
+		return "", ErrExportNotFound
+	}
+
+	return filepath.Join(e.exportDir, export.FileName), nil
+}
+
+// RunWorker build the pending exports and remove the expired archives until
+// stop is closed, the exports already claimed are built with ctx. Several API
+// instances can run the worker at the same time.
+func (e *exportServiceImpl) RunWorker(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(exportWorkerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			exports, err := e.repo.ClaimPendingExports(ctx, time.Now(), exportClaimLease, exportClaimBatchSize)
+			if err != nil {
+				slog.ErrorContext(ctx, "cannot claim data exports", "error", err)
+				continue
+			}
+
+			for _, export := range exports {
+				e.process(ctx, export)
+			}
+
+			e.expire(ctx)
+		}
+	}
+}
+
+func (e *exportServiceImpl) process(ctx context.Context, export model.DataExport) {
+	now := time.Now()
+	export.Attempts++
+	export.UpdatedAt = now
+
+	fileName, err := e.build(ctx, export.UserId)
+	if err != nil {
+		export.Error = err.Error()
+		if export.Attempts >= exportMaxAttempts {
+			export.Status = model.DataExportFailed
+		}
+	} else {
+		token, err := helper.GenerateSecret(32)
+		if err != nil {
+			slog.ErrorContext(ctx, "cannot generate export download token", "error", err)
+			return
+		}
+
+		expiresAt := now.Add(exportLinkTTL)
+		export.Status = model.DataExportReady
+		export.Error = ""
+		export.FileName = fileName
+		export.DownloadToken = token
+		export.ExpiresAt = &expiresAt
+		export.CompletedAt = &now
+	}
+	export.ClaimedUntil = nil
+
+	err = e.repo.UpdateExport(ctx, &export)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot update data export", "error", err)
+	}
+}
+
+// expire remove the archives whose download link has expired.
+func (e *exportServiceImpl) expire(ctx context.Context) {
+	exports, err := e.repo.GetExpiredExports(ctx, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot get expired data exports", "error", err)
+		return
+	}
+
+	for _, export := range exports {
+		err = os.Remove(filepath.Join(e.exportDir, export.FileName))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			slog.ErrorContext(ctx, "cannot remove data export file", "error", err)
+			continue
+		}
+
+		export.Status = model.DataExportExpired
+		export.FileName = ""
+		export.DownloadToken = ""
+		export.UpdatedAt = time.Now()
+
+		err = e.repo.UpdateExport(ctx, &export)
+		if err != nil {
+			slog.ErrorContext(ctx, "cannot update data export", "error", err)
+		}
+	}
+}
+
+// build write the archive of the user data in the export directory and
+// return its file name, the photo files which are not in the storage are only
+// referenced by their url in photos.json.
+func (e *exportServiceImpl) build(ctx context.Context, userId publicid.ID) (fileName string, err error) {
+	user, err := e.userRepo.GetUserById(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+	if user.ID == 0 {
+		return "", errors.New("user did not exist")
+	}
+
+	photos, err := e.repo.GetUserPhotos(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+
+	comments, err := e.repo.GetUserComments(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+
+	socials, err := e.repo.GetUserSocialMedias(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+
+	follows, err := e.repo.GetUserFollows(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+
+	reactions, err := e.repo.GetUserReactions(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(e.exportDir, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	fileName = uuid.NewString() + ".zip"
+	file, err := os.OpenFile(filepath.Join(e.exportDir, fileName), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(file.Name())
+		}
+	}()
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	jsonFiles := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", user},
+		{"photos.json", photos},
+		{"comments.json", comments},
+		{"social_medias.json", socials},
+		{"follows.json", follows},
+		{"likes.json", reactions},
+	}
+
+	for _, jsonFile := range jsonFiles {
+		err = writeJSONFile(archive, jsonFile.name, jsonFile.data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	for _, photo := range photos {
+		image, err := e.storage.Read(ctx, photo.PhotoUrl)
+		if errors.Is(err, infrastructure.ErrFileNotInStorage) || errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		entry, err := archive.Create(fmt.Sprintf("photos/%s%s", photo.ID, path.Ext(photo.PhotoUrl)))
+		if err != nil {
+			return "", err
+		}
+
+		_, err = entry.Write(image)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func writeJSONFile(archive *zip.Writer, name string, data interface{}) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// ToDataExportRes only set the download url of the ready exports.
+func ToDataExportRes(export model.DataExport) *model.DataExportRes {
+	exportRes := model.DataExportRes{}
+	exportRes.ID = export.ID
+	exportRes.Status = export.Status
+	exportRes.Error = export.Error
+	exportRes.ExpiresAt = export.ExpiresAt
+	exportRes.CompletedAt = export.CompletedAt
+	exportRes.CreatedAt = export.CreatedAt
+
+	if export.Status == model.DataExportReady && export.DownloadToken != "" {
+		exportRes.DownloadUrl = fmt.Sprintf("/v1/exports/%s/download?token=%s", export.ID, export.DownloadToken)
+	}
+
+	return &exportRes
+}
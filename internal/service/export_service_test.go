@@ -0,0 +1,20 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zikri124/mygram-api/internal/model"
+)
+
+func TestToDataExportRes(t *testing.T) {
+	export := model.DataExport{ID: 5, Status: model.DataExportPending, DownloadToken: "abc"}
+	assert.Equal(t, "", ToDataExportRes(export).DownloadUrl)
+
+	export.Status = model.DataExportReady
+	assert.Equal(t, "/v1/exports/"+export.ID.String()+"/download?token=abc", ToDataExportRes(export).DownloadUrl)
+
+	export.Status = model.DataExportExpired
+	export.DownloadToken = ""
+	assert.Equal(t, "", ToDataExportRes(export).DownloadUrl)
+}
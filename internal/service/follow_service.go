@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+type FollowService interface {
+	Follow(ctx context.Context, followerId publicid.ID, followeeId publicid.ID) error
+	Unfollow(ctx context.Context, followerId publicid.ID, followeeId publicid.ID) error
+}
+
+type followServiceImpl struct {
+	repo     repository.FollowRepository
+	notifSvc NotificationService
+}
+
+func NewFollowService(repo repository.FollowRepository, notifSvc NotificationService) FollowService {
+	return &followServiceImpl{repo: repo, notifSvc: notifSvc}
+}
+
+// Follow is idempotent, the followee is only notified the first time.
+func (f *followServiceImpl) Follow(ctx context.Context, followerId publicid.ID, followeeId publicid.ID) error {
+	follow := model.Follow{FollowerId: followerId, FolloweeId: followeeId}
+
+	created, err := f.repo.CreateFollow(ctx, &follow)
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		return nil
+	}
+
+	return f.notifSvc.Notify(ctx, model.Notification{
+		UserId:     followeeId,
+		ActorId:    followerId,
+		Type:       model.NotificationTypeFollow,
+		EntityType: "user",
+		EntityId:   followeeId,
+	})
+}
+
+func (f *followServiceImpl) Unfollow(ctx context.Context, followerId publicid.ID, followeeId publicid.ID) error {
+	return f.repo.DeleteFollow(ctx, followerId, followeeId)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/model"
+)
+
+var ErrShuttingDown = errors.New("server is shutting down")
+
+type HealthConfig struct {
+	// CheckTimeout is how long each readiness check can take.
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT"`
+}
+
+type HealthService interface {
+	// Register add a dependency checked by Ready, check must return an error
+	// when the dependency is not usable.
+	Register(name string, check func(ctx context.Context) error)
+	// Ready run every check concurrently, the API is ready when they all pass
+	// and it is not shutting down.
+	Ready(ctx context.Context) (bool, []model.HealthCheck)
+	// ShutDown make the API not ready so the load balancers stop sending it
+	// new requests.
+	ShutDown()
+}
+
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+type healthServiceImpl struct {
+	config       HealthConfig
+	mu           sync.RWMutex
+	checks       []healthCheck
+	shuttingDown atomic.Bool
+}
+
+func NewHealthService(config HealthConfig) HealthService {
+	return &healthServiceImpl{config: config}
+}
+
+func (h *healthServiceImpl) Register(name string, check func(ctx context.Context) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, healthCheck{name: name, check: check})
+}
+
+func (h *healthServiceImpl) Ready(ctx context.Context) (bool, []model.HealthCheck) {
+	h.mu.RLock()
+	checks := h.checks
+	h.mu.RUnlock()
+
+	results := make([]model.HealthCheck, len(checks))
+	wg := sync.WaitGroup{}
+
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check healthCheck) {
+			defer wg.Done()
+			results[i] = h.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	ready := !h.shuttingDown.Load()
+	if !ready {
+		results = append(results, model.HealthCheck{Name: "shutdown", Status: model.HealthStatusDown, Error: ErrShuttingDown.Error()})
+	}
+
+	for _, result := range results {
+		if result.Status != model.HealthStatusUp {
+			ready = false
+		}
+	}
+
+	return ready, results
+}
+
+func (h *healthServiceImpl) run(ctx context.Context, check healthCheck) model.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, h.config.CheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	// a check ignoring ctx must not hold the probe past the timeout
+	done := make(chan error, 1)
+	go func() {
+		done <- check.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := model.HealthCheck{Name: check.name, Status: model.HealthStatusUp, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = model.HealthStatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func (h *healthServiceImpl) ShutDown() {
+	h.shuttingDown.Store(true)
+}
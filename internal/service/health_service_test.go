@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zikri124/mygram-api/internal/model"
+)
+
+func TestHealthServiceReady(t *testing.T) {
+	healthService := NewHealthService(HealthConfig{CheckTimeout: 50 * time.Millisecond})
+	healthService.Register("postgres", func(ctx context.Context) error {
+		return nil
+	})
+
+	ready, checks := healthService.Ready(context.Background())
+	assert.True(t, ready)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, "postgres", checks[0].Name)
+	assert.Equal(t, model.HealthStatusUp, checks[0].Status)
+
+	block := make(chan struct{})
+	defer close(block)
+	healthService.Register("broker", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+	healthService.Register("slow", func(ctx context.Context) error {
+		<-block
+		return nil
+	})
+
+	ready, checks = healthService.Ready(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, "connection refused", checks[1].Error)
+	assert.Equal(t, context.DeadlineExceeded.Error(), checks[2].Error)
+
+	healthService = NewHealthService(HealthConfig{CheckTimeout: time.Second})
+	healthService.ShutDown()
+
+	ready, checks = healthService.Ready(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, ErrShuttingDown.Error(), checks[0].Error)
+}
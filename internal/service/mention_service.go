@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+type MentionService interface {
+	SyncMentions(ctx context.Context, sourceType string, sourceId publicid.ID, authorId publicid.ID, text string) ([]model.MentionView, error)
+}
+
+type mentionServiceImpl struct {
+	repo     repository.MentionRepository
+	userRepo repository.UserRepository
+	notifSvc NotificationService
+}
+
+func NewMentionService(repo repository.MentionRepository, userRepo repository.UserRepository, notifSvc NotificationService) MentionService {
+	return &mentionServiceImpl{repo: repo, userRepo: userRepo, notifSvc: notifSvc}
+}
+
+// SyncMentions resolve the "@username" of a photo caption or a comment message
+// to users, store them and notify the users who were not mentioned in the
+// previous version of the text. Unknown usernames and the users who cannot
+// see the source are ignored.
+func (m *mentionServiceImpl) SyncMentions(ctx context.Context, sourceType string, sourceId publicid.ID, authorId publicid.ID, text string) ([]model.MentionView, error) {
+	tokens := helper.ExtractMentions(text)
+
+	users := []model.User{}
+	if len(tokens) > 0 {
+		usernames := []string{}
+		for _, token := range tokens {
+			usernames = append(usernames, token.Username)
+		}
+
+		var err error
+		users, err = m.userRepo.GetUsersByUsernames(ctx, usernames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	users, err := m.filterUsersSeeingSource(ctx, sourceType, sourceId, users)
+	if err != nil {
+		return nil, err
+	}
+
+	usersByName := map[string]model.User{}
+	for _, user := range users {
+		usersByName[strings.ToLower(user.Username)] = user
+	}
+
+	previousMentions, err := m.repo.GetMentionsBySource(ctx, sourceType, sourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	alreadyMentioned := map[publicid.ID]bool{}
+	for _, mention := range previousMentions {
+		alreadyMentioned[mention.UserId] = true
+	}
+
+	mentions := []model.Mention{}
+	mentionViews := []model.MentionView{}
+	notifications := []model.Notification{}
+	for _, token := range tokens {
+		user, ok := usersByName[strings.ToLower(token.Username)]
+		if !ok {
+			continue
+		}
+
+		mention := model.Mention{SourceType: sourceType, SourceId: sourceId, UserId: user.ID, Offset: token.Offset, Length: token.Length}
+		mentions = append(mentions, mention)
+		mentionViews = append(mentionViews, model.MentionView{
+			SourceType: sourceType,
+			SourceId:   sourceId,
+			UserId:     user.ID,
+			Offset:     token.Offset,
+			Length:     token.Length,
+			User:       model.UserItem{ID: user.ID, Username: user.Username},
+		})
+
+		if !alreadyMentioned[user.ID] {
+			alreadyMentioned[user.ID] = true
+			notifications = append(notifications, model.Notification{
+				UserId:     user.ID,
+				ActorId:    authorId,
+				Type:       model.NotificationTypeMention,
+				EntityType: sourceType,
+				EntityId:   sourceId,
+			})
+		}
+	}
+
+	err = m.repo.ReplaceMentions(ctx, sourceType, sourceId, mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.notifSvc.Notify(ctx, notifications...)
+	if err != nil {
+		return nil, err
+	}
+
+	return mentionViews, nil
+}
+
+// filterUsersSeeingSource keep the users allowed to see the source, the other
+// users must not learn about it from a mention.
+func (m *mentionServiceImpl) filterUsersSeeingSource(ctx context.Context, sourceType string, sourceId publicid.ID, users []model.User) ([]model.User, error) {
+	if len(users) == 0 {
+		return users, nil
+	}
+
+	userIds := []publicid.ID{}
+	for _, user := range users {
+		userIds = append(userIds, user.ID)
+	}
+
+	visibleIds, err := m.repo.GetUsersSeeingSource(ctx, sourceType, sourceId, userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	canSee := map[publicid.ID]bool{}
+	for _, id := range visibleIds {
+		canSee[id] = true
+	}
+
+	visibleUsers := []model.User{}
+	for _, user := range users {
+		if canSee[user.ID] {
+			visibleUsers = append(visibleUsers, user)
+		}
+	}
+
+	return visibleUsers, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zikri124/mygram-api/internal/model"
+	repoMocks "github.com/zikri124/mygram-api/internal/repository/mocks"
+	"github.com/zikri124/mygram-api/internal/service/mocks"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+func TestSyncMentions(t *testing.T) {
+	ctx := context.Background()
+	alice := model.User{ID: 10, Username: "alice"}
+	bob := model.User{ID: 11, Username: "Bob"}
+
+	newMentionService := func(t *testing.T) (*mentionServiceImpl, *repoMocks.MentionRepository, *repoMocks.UserRepository, *mocks.NotificationService) {
+		repo := repoMocks.NewMentionRepository(t)
+		userRepo := repoMocks.NewUserRepository(t)
+		notifSvc := mocks.NewNotificationService(t)
+		return &mentionServiceImpl{repo: repo, userRepo: userRepo, notifSvc: notifSvc}, repo, userRepo, notifSvc
+	}
+
+	t.Run("mention and notify the users seeing the source", func(t *testing.T) {
+		mentionService, repo, userRepo, notifSvc := newMentionService(t)
+
+		userRepo.On("GetUsersByUsernames", ctx, []string{"alice", "bob", "nobody"}).Return([]model.User{alice, bob}, nil)
+		repo.On("GetUsersSeeingSource", ctx, model.MentionSourceComment, publicid.ID(5), []publicid.ID{10, 11}).Return([]publicid.ID{10, 11}, nil)
+		repo.On("GetMentionsBySource", ctx, model.MentionSourceComment, publicid.ID(5)).Return([]model.MentionView{{UserId: 10}}, nil)
+		repo.On("ReplaceMentions", ctx, model.MentionSourceComment, publicid.ID(5), mock.MatchedBy(func(mentions []model.Mention) bool {
+			return len(mentions) == 2
+		})).Return(nil)
+		notifSvc.On("Notify", ctx, model.Notification{
+			UserId:     11,
+			ActorId:    1,
+			Type:       model.NotificationTypeMention,
+			EntityType: model.MentionSourceComment,
+			EntityId:   5,
+		}).Return(nil)
+
+		mentions, err := mentionService.SyncMentions(ctx, model.MentionSourceComment, 5, 1, "@alice @bob @nobody")
+		assert.Nil(t, err)
+		assert.Len(t, mentions, 2)
+		assert.Equal(t, 0, mentions[0].Offset)
+		assert.Equal(t, "Bob", mentions[1].User.Username)
+	})
+
+	t.Run("users who cannot see the source are not mentioned", func(t *testing.T) {
+		mentionService, repo, userRepo, notifSvc := newMentionService(t)
+
+		userRepo.On("GetUsersByUsernames", ctx, []string{"alice", "bob"}).Return([]model.User{alice, bob}, nil)
+		repo.On("GetUsersSeeingSource", ctx, model.MentionSourcePhoto, publicid.ID(7), []publicid.ID{10, 11}).Return([]publicid.ID{11}, nil)
+		repo.On("GetMentionsBySource", ctx, model.MentionSourcePhoto, publicid.ID(7)).Return([]model.MentionView{}, nil)
+		repo.On("ReplaceMentions", ctx, model.MentionSourcePhoto, publicid.ID(7), mock.MatchedBy(func(mentions []model.Mention) bool {
+			return len(mentions) == 1 && mentions[0].UserId == 11
+		})).Return(nil)
+		notifSvc.On("Notify", ctx, mock.MatchedBy(func(notification model.Notification) bool {
+			return notification.UserId == 11
+		})).Return(nil)
+
+		mentions, err := mentionService.SyncMentions(ctx, model.MentionSourcePhoto, 7, 1, "@alice and @bob")
+		assert.Nil(t, err)
+		assert.Len(t, mentions, 1)
+		assert.Equal(t, publicid.ID(11), mentions[0].UserId)
+	})
+
+	t.Run("text without mention clear the previous ones", func(t *testing.T) {
+		mentionService, repo, _, notifSvc := newMentionService(t)
+
+		repo.On("GetMentionsBySource", ctx, model.MentionSourcePhoto, publicid.ID(7)).Return([]model.MentionView{{UserId: 10}}, nil)
+		repo.On("ReplaceMentions", ctx, model.MentionSourcePhoto, publicid.ID(7), []model.Mention{}).Return(nil)
+		notifSvc.On("Notify", ctx).Return(nil)
+
+		mentions, err := mentionService.SyncMentions(ctx, model.MentionSourcePhoto, 7, 1, "no mention")
+		assert.Nil(t, err)
+		assert.Empty(t, mentions)
+	})
+}
@@ -7,6 +7,10 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	model "github.com/zikri124/mygram-api/internal/model"
+
+	pagination "github.com/zikri124/mygram-api/pkg/pagination"
+
+	publicid "github.com/zikri124/mygram-api/pkg/publicid"
 )
 
 // CommentService is an autogenerated mock type for the CommentService type
@@ -15,7 +19,7 @@ type CommentService struct {
 }
 
 // DeleteComment provides a mock function with given fields: ctx, commentId
-func (_m *CommentService) DeleteComment(ctx context.Context, commentId uint32) error {
+func (_m *CommentService) DeleteComment(ctx context.Context, commentId publicid.ID) error {
 	ret := _m.Called(ctx, commentId)
 
 	if len(ret) == 0 {
@@ -23,7 +27,7 @@ func (_m *CommentService) DeleteComment(ctx context.Context, commentId uint32) e
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) error); ok {
 		r0 = rf(ctx, commentId)
 	} else {
 		r0 = ret.Error(0)
@@ -32,29 +36,29 @@ func (_m *CommentService) DeleteComment(ctx context.Context, commentId uint32) e
 	return r0
 }
 
-// GetAllCommentsByPhotoId provides a mock function with given fields: ctx, photoId
-func (_m *CommentService) GetAllCommentsByPhotoId(ctx context.Context, photoId uint32) ([]model.CommentView, error) {
-	ret := _m.Called(ctx, photoId)
+// GetAllCommentsByPhotoId provides a mock function with given fields: ctx, photoId, params, viewerId
+func (_m *CommentService) GetAllCommentsByPhotoId(ctx context.Context, photoId publicid.ID, params pagination.Params, viewerId publicid.ID) (*pagination.Page, error) {
+	ret := _m.Called(ctx, photoId, params, viewerId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllCommentsByPhotoId")
 	}
 
-	var r0 []model.CommentView
+	var r0 *pagination.Page
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) ([]model.CommentView, error)); ok {
-		return rf(ctx, photoId)
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, pagination.Params, publicid.ID) (*pagination.Page, error)); ok {
+		return rf(ctx, photoId, params, viewerId)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) []model.CommentView); ok {
-		r0 = rf(ctx, photoId)
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, pagination.Params, publicid.ID) *pagination.Page); ok {
+		r0 = rf(ctx, photoId, params, viewerId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.CommentView)
+			r0 = ret.Get(0).(*pagination.Page)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
-		r1 = rf(ctx, photoId)
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, pagination.Params, publicid.ID) error); ok {
+		r1 = rf(ctx, photoId, params, viewerId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,27 +67,27 @@ func (_m *CommentService) GetAllCommentsByPhotoId(ctx context.Context, photoId u
 }
 
 // GetCommentById provides a mock function with given fields: ctx, commentId
-func (_m *CommentService) GetCommentById(ctx context.Context, commentId uint32) (*model.Comment, error) {
+func (_m *CommentService) GetCommentById(ctx context.Context, commentId publicid.ID) (*model.CommentView, error) {
 	ret := _m.Called(ctx, commentId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCommentById")
 	}
 
-	var r0 *model.Comment
+	var r0 *model.CommentView
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) (*model.Comment, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) (*model.CommentView, error)); ok {
 		return rf(ctx, commentId)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) *model.Comment); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) *model.CommentView); ok {
 		r0 = rf(ctx, commentId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Comment)
+			r0 = ret.Get(0).(*model.CommentView)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID) error); ok {
 		r1 = rf(ctx, commentId)
 	} else {
 		r1 = ret.Error(1)
@@ -92,8 +96,38 @@ func (_m *CommentService) GetCommentById(ctx context.Context, commentId uint32)
 	return r0, r1
 }
 
+// GetRepliesByCommentId provides a mock function with given fields: ctx, commentId, params, viewerId
+func (_m *CommentService) GetRepliesByCommentId(ctx context.Context, commentId publicid.ID, params pagination.Params, viewerId publicid.ID) (*model.CommentRepliesRes, error) {
+	ret := _m.Called(ctx, commentId, params, viewerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepliesByCommentId")
+	}
+
+	var r0 *model.CommentRepliesRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, pagination.Params, publicid.ID) (*model.CommentRepliesRes, error)); ok {
+		return rf(ctx, commentId, params, viewerId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, pagination.Params, publicid.ID) *model.CommentRepliesRes); ok {
+		r0 = rf(ctx, commentId, params, viewerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CommentRepliesRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, pagination.Params, publicid.ID) error); ok {
+		r1 = rf(ctx, commentId, params, viewerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PostComment provides a mock function with given fields: ctx, userId, newComment
-func (_m *CommentService) PostComment(ctx context.Context, userId uint32, newComment model.CreateComment) (*model.CreateCommentRes, error) {
+func (_m *CommentService) PostComment(ctx context.Context, userId publicid.ID, newComment model.CreateComment) (*model.CreateCommentRes, error) {
 	ret := _m.Called(ctx, userId, newComment)
 
 	if len(ret) == 0 {
@@ -102,10 +136,10 @@ func (_m *CommentService) PostComment(ctx context.Context, userId uint32, newCom
 
 	var r0 *model.CreateCommentRes
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, model.CreateComment) (*model.CreateCommentRes, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.CreateComment) (*model.CreateCommentRes, error)); ok {
 		return rf(ctx, userId, newComment)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, model.CreateComment) *model.CreateCommentRes); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.CreateComment) *model.CreateCommentRes); ok {
 		r0 = rf(ctx, userId, newComment)
 	} else {
 		if ret.Get(0) != nil {
@@ -113,7 +147,7 @@ func (_m *CommentService) PostComment(ctx context.Context, userId uint32, newCom
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32, model.CreateComment) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, model.CreateComment) error); ok {
 		r1 = rf(ctx, userId, newComment)
 	} else {
 		r1 = ret.Error(1)
@@ -7,6 +7,10 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	model "github.com/zikri124/mygram-api/internal/model"
+
+	pagination "github.com/zikri124/mygram-api/pkg/pagination"
+
+	publicid "github.com/zikri124/mygram-api/pkg/publicid"
 )
 
 // PhotoService is an autogenerated mock type for the PhotoService type
@@ -15,7 +19,7 @@ type PhotoService struct {
 }
 
 // DeletePhoto provides a mock function with given fields: ctx, photoId
-func (_m *PhotoService) DeletePhoto(ctx context.Context, photoId uint32) error {
+func (_m *PhotoService) DeletePhoto(ctx context.Context, photoId publicid.ID) error {
 	ret := _m.Called(ctx, photoId)
 
 	if len(ret) == 0 {
@@ -23,7 +27,7 @@ func (_m *PhotoService) DeletePhoto(ctx context.Context, photoId uint32) error {
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) error); ok {
 		r0 = rf(ctx, photoId)
 	} else {
 		r0 = ret.Error(0)
@@ -32,29 +36,29 @@ func (_m *PhotoService) DeletePhoto(ctx context.Context, photoId uint32) error {
 	return r0
 }
 
-// GetAllPhotosByUserId provides a mock function with given fields: ctx, userId
-func (_m *PhotoService) GetAllPhotosByUserId(ctx context.Context, userId uint32) ([]model.PhotoView, error) {
-	ret := _m.Called(ctx, userId)
+// GetAllPhotosByUserId provides a mock function with given fields: ctx, userId, params
+func (_m *PhotoService) GetAllPhotosByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) (*pagination.Page, error) {
+	ret := _m.Called(ctx, userId, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllPhotosByUserId")
 	}
 
-	var r0 []model.PhotoView
+	var r0 *pagination.Page
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) ([]model.PhotoView, error)); ok {
-		return rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, pagination.Params) (*pagination.Page, error)); ok {
+		return rf(ctx, userId, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) []model.PhotoView); ok {
-		r0 = rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, pagination.Params) *pagination.Page); ok {
+		r0 = rf(ctx, userId, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.PhotoView)
+			r0 = ret.Get(0).(*pagination.Page)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
-		r1 = rf(ctx, userId)
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, pagination.Params) error); ok {
+		r1 = rf(ctx, userId, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,27 +67,27 @@ func (_m *PhotoService) GetAllPhotosByUserId(ctx context.Context, userId uint32)
 }
 
 // GetPhotoById provides a mock function with given fields: ctx, photoId
-func (_m *PhotoService) GetPhotoById(ctx context.Context, photoId uint32) (*model.Photo, error) {
+func (_m *PhotoService) GetPhotoById(ctx context.Context, photoId publicid.ID) (*model.PhotoView, error) {
 	ret := _m.Called(ctx, photoId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPhotoById")
 	}
 
-	var r0 *model.Photo
+	var r0 *model.PhotoView
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) (*model.Photo, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) (*model.PhotoView, error)); ok {
 		return rf(ctx, photoId)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) *model.Photo); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) *model.PhotoView); ok {
 		r0 = rf(ctx, photoId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Photo)
+			r0 = ret.Get(0).(*model.PhotoView)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID) error); ok {
 		r1 = rf(ctx, photoId)
 	} else {
 		r1 = ret.Error(1)
@@ -152,6 +156,36 @@ func (_m *PhotoService) UpdatePhoto(ctx context.Context, photo model.Photo) (*mo
 	return r0, r1
 }
 
+// UploadPhoto provides a mock function with given fields: ctx, userId, upload, image
+func (_m *PhotoService) UploadPhoto(ctx context.Context, userId publicid.ID, upload model.PhotoUpload, image []byte) (*model.PhotoResCreate, error) {
+	ret := _m.Called(ctx, userId, upload, image)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadPhoto")
+	}
+
+	var r0 *model.PhotoResCreate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.PhotoUpload, []byte) (*model.PhotoResCreate, error)); ok {
+		return rf(ctx, userId, upload, image)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.PhotoUpload, []byte) *model.PhotoResCreate); ok {
+		r0 = rf(ctx, userId, upload, image)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PhotoResCreate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, model.PhotoUpload, []byte) error); ok {
+		r1 = rf(ctx, userId, upload, image)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPhotoService creates a new instance of PhotoService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPhotoService(t interface {
@@ -7,6 +7,10 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	model "github.com/zikri124/mygram-api/internal/model"
+
+	pagination "github.com/zikri124/mygram-api/pkg/pagination"
+
+	publicid "github.com/zikri124/mygram-api/pkg/publicid"
 )
 
 // SocialMediaService is an autogenerated mock type for the SocialMediaService type
@@ -15,7 +19,7 @@ type SocialMediaService struct {
 }
 
 // DeleteSocial provides a mock function with given fields: ctx, socialId
-func (_m *SocialMediaService) DeleteSocial(ctx context.Context, socialId uint32) error {
+func (_m *SocialMediaService) DeleteSocial(ctx context.Context, socialId publicid.ID) error {
 	ret := _m.Called(ctx, socialId)
 
 	if len(ret) == 0 {
@@ -23,7 +27,7 @@ func (_m *SocialMediaService) DeleteSocial(ctx context.Context, socialId uint32)
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) error); ok {
 		r0 = rf(ctx, socialId)
 	} else {
 		r0 = ret.Error(0)
@@ -32,29 +36,29 @@ func (_m *SocialMediaService) DeleteSocial(ctx context.Context, socialId uint32)
 	return r0
 }
 
-// GetAllSocialMediasByUserId provides a mock function with given fields: ctx, userId
-func (_m *SocialMediaService) GetAllSocialMediasByUserId(ctx context.Context, userId uint32) ([]model.SocialMediaView, error) {
-	ret := _m.Called(ctx, userId)
+// GetAllSocialMediasByUserId provides a mock function with given fields: ctx, userId, params
+func (_m *SocialMediaService) GetAllSocialMediasByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) (*pagination.Page, error) {
+	ret := _m.Called(ctx, userId, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllSocialMediasByUserId")
 	}
 
-	var r0 []model.SocialMediaView
+	var r0 *pagination.Page
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) ([]model.SocialMediaView, error)); ok {
-		return rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, pagination.Params) (*pagination.Page, error)); ok {
+		return rf(ctx, userId, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) []model.SocialMediaView); ok {
-		r0 = rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, pagination.Params) *pagination.Page); ok {
+		r0 = rf(ctx, userId, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.SocialMediaView)
+			r0 = ret.Get(0).(*pagination.Page)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
-		r1 = rf(ctx, userId)
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, pagination.Params) error); ok {
+		r1 = rf(ctx, userId, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,27 +67,27 @@ func (_m *SocialMediaService) GetAllSocialMediasByUserId(ctx context.Context, us
 }
 
 // GetSocialById provides a mock function with given fields: ctx, socialId
-func (_m *SocialMediaService) GetSocialById(ctx context.Context, socialId uint32) (*model.SocialMedia, error) {
+func (_m *SocialMediaService) GetSocialById(ctx context.Context, socialId publicid.ID) (*model.SocialMediaView, error) {
 	ret := _m.Called(ctx, socialId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSocialById")
 	}
 
-	var r0 *model.SocialMedia
+	var r0 *model.SocialMediaView
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) (*model.SocialMedia, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) (*model.SocialMediaView, error)); ok {
 		return rf(ctx, socialId)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) *model.SocialMedia); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) *model.SocialMediaView); ok {
 		r0 = rf(ctx, socialId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.SocialMedia)
+			r0 = ret.Get(0).(*model.SocialMediaView)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID) error); ok {
 		r1 = rf(ctx, socialId)
 	} else {
 		r1 = ret.Error(1)
@@ -93,7 +97,7 @@ func (_m *SocialMediaService) GetSocialById(ctx context.Context, socialId uint32
 }
 
 // PostSocial provides a mock function with given fields: ctx, userId, social
-func (_m *SocialMediaService) PostSocial(ctx context.Context, userId uint32, social model.NewSocialMedia) (*model.CreateSocialMediaRes, error) {
+func (_m *SocialMediaService) PostSocial(ctx context.Context, userId publicid.ID, social model.NewSocialMedia) (*model.CreateSocialMediaRes, error) {
 	ret := _m.Called(ctx, userId, social)
 
 	if len(ret) == 0 {
@@ -102,10 +106,10 @@ func (_m *SocialMediaService) PostSocial(ctx context.Context, userId uint32, soc
 
 	var r0 *model.CreateSocialMediaRes
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, model.NewSocialMedia) (*model.CreateSocialMediaRes, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.NewSocialMedia) (*model.CreateSocialMediaRes, error)); ok {
 		return rf(ctx, userId, social)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, model.NewSocialMedia) *model.CreateSocialMediaRes); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.NewSocialMedia) *model.CreateSocialMediaRes); ok {
 		r0 = rf(ctx, userId, social)
 	} else {
 		if ret.Get(0) != nil {
@@ -113,7 +117,7 @@ func (_m *SocialMediaService) PostSocial(ctx context.Context, userId uint32, soc
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32, model.NewSocialMedia) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, model.NewSocialMedia) error); ok {
 		r1 = rf(ctx, userId, social)
 	} else {
 		r1 = ret.Error(1)
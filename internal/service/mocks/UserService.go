@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	model "github.com/zikri124/mygram-api/internal/model"
+
+	publicid "github.com/zikri124/mygram-api/pkg/publicid"
 )
 
 // UserService is an autogenerated mock type for the UserService type
@@ -43,7 +45,7 @@ func (_m *UserService) CheckIsAValidAge(dobStr string) (bool, error) {
 }
 
 // DeleteUser provides a mock function with given fields: ctx, userId
-func (_m *UserService) DeleteUser(ctx context.Context, userId uint32) error {
+func (_m *UserService) DeleteUser(ctx context.Context, userId publicid.ID) error {
 	ret := _m.Called(ctx, userId)
 
 	if len(ret) == 0 {
@@ -51,7 +53,7 @@ func (_m *UserService) DeleteUser(ctx context.Context, userId uint32) error {
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) error); ok {
 		r0 = rf(ctx, userId)
 	} else {
 		r0 = ret.Error(0)
@@ -118,8 +120,68 @@ func (_m *UserService) GenerateAccessToken(ctx context.Context, user model.User)
 	return r0, r1
 }
 
+// GetPrivacySettings provides a mock function with given fields: ctx, userId
+func (_m *UserService) GetPrivacySettings(ctx context.Context, userId publicid.ID) (*model.PrivacySettings, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPrivacySettings")
+	}
+
+	var r0 *model.PrivacySettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) (*model.PrivacySettings, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) *model.PrivacySettings); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PrivacySettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicUserById provides a mock function with given fields: ctx, userId, viewer
+func (_m *UserService) GetPublicUserById(ctx context.Context, userId publicid.ID, viewer model.Viewer) (*model.UserPublicView, error) {
+	ret := _m.Called(ctx, userId, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicUserById")
+	}
+
+	var r0 *model.UserPublicView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.Viewer) (*model.UserPublicView, error)); ok {
+		return rf(ctx, userId, viewer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.Viewer) *model.UserPublicView); ok {
+		r0 = rf(ctx, userId, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserPublicView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, model.Viewer) error); ok {
+		r1 = rf(ctx, userId, viewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserById provides a mock function with given fields: ctx, userId
-func (_m *UserService) GetUserById(ctx context.Context, userId uint32) (*model.UserView, error) {
+func (_m *UserService) GetUserById(ctx context.Context, userId publicid.ID) (*model.UserView, error) {
 	ret := _m.Called(ctx, userId)
 
 	if len(ret) == 0 {
@@ -128,10 +190,10 @@ func (_m *UserService) GetUserById(ctx context.Context, userId uint32) (*model.U
 
 	var r0 *model.UserView
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) (*model.UserView, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) (*model.UserView, error)); ok {
 		return rf(ctx, userId)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32) *model.UserView); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) *model.UserView); ok {
 		r0 = rf(ctx, userId)
 	} else {
 		if ret.Get(0) != nil {
@@ -139,7 +201,7 @@ func (_m *UserService) GetUserById(ctx context.Context, userId uint32) (*model.U
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID) error); ok {
 		r1 = rf(ctx, userId)
 	} else {
 		r1 = ret.Error(1)
@@ -148,6 +210,221 @@ func (_m *UserService) GetUserById(ctx context.Context, userId uint32) (*model.U
 	return r0, r1
 }
 
+// GetUserByUsername provides a mock function with given fields: ctx, username, viewer
+func (_m *UserService) GetUserByUsername(ctx context.Context, username string, viewer model.Viewer) (*model.UserPublicView, error) {
+	ret := _m.Called(ctx, username, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByUsername")
+	}
+
+	var r0 *model.UserPublicView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Viewer) (*model.UserPublicView, error)); ok {
+		return rf(ctx, username, viewer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Viewer) *model.UserPublicView); ok {
+		r0 = rf(ctx, username, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserPublicView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.Viewer) error); ok {
+		r1 = rf(ctx, username, viewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserProfile provides a mock function with given fields: ctx, userId, viewer
+func (_m *UserService) GetUserProfile(ctx context.Context, userId publicid.ID, viewer model.Viewer) (*model.UserProfile, error) {
+	ret := _m.Called(ctx, userId, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserProfile")
+	}
+
+	var r0 *model.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.Viewer) (*model.UserProfile, error)); ok {
+		return rf(ctx, userId, viewer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.Viewer) *model.UserProfile); ok {
+		r0 = rf(ctx, userId, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, model.Viewer) error); ok {
+		r1 = rf(ctx, userId, viewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsernameSuggestions provides a mock function with given fields: ctx, prefix, limit
+func (_m *UserService) GetUsernameSuggestions(ctx context.Context, prefix string, limit int) ([]model.UserSuggestion, error) {
+	ret := _m.Called(ctx, prefix, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsernameSuggestions")
+	}
+
+	var r0 []model.UserSuggestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]model.UserSuggestion, error)); ok {
+		return rf(ctx, prefix, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []model.UserSuggestion); ok {
+		r0 = rf(ctx, prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserSuggestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveAvatar provides a mock function with given fields: ctx, userId
+func (_m *UserService) RemoveAvatar(ctx context.Context, userId publicid.ID) (*model.UserProfile, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAvatar")
+	}
+
+	var r0 *model.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) (*model.UserProfile, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID) *model.UserProfile); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunPurgeWorker provides a mock function with given fields: ctx, stop
+func (_m *UserService) RunPurgeWorker(ctx context.Context, stop <-chan struct{}) {
+	_m.Called(ctx, stop)
+}
+
+// UpdateAvatar provides a mock function with given fields: ctx, userId, image
+func (_m *UserService) UpdateAvatar(ctx context.Context, userId publicid.ID, image []byte) (*model.UserProfile, error) {
+	ret := _m.Called(ctx, userId, image)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAvatar")
+	}
+
+	var r0 *model.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, []byte) (*model.UserProfile, error)); ok {
+		return rf(ctx, userId, image)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, []byte) *model.UserProfile); ok {
+		r0 = rf(ctx, userId, image)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, []byte) error); ok {
+		r1 = rf(ctx, userId, image)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePrivacySettings provides a mock function with given fields: ctx, userId, update
+func (_m *UserService) UpdatePrivacySettings(ctx context.Context, userId publicid.ID, update model.UpdatePrivacySettings) (*model.PrivacySettings, error) {
+	ret := _m.Called(ctx, userId, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrivacySettings")
+	}
+
+	var r0 *model.PrivacySettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.UpdatePrivacySettings) (*model.PrivacySettings, error)); ok {
+		return rf(ctx, userId, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.UpdatePrivacySettings) *model.PrivacySettings); ok {
+		r0 = rf(ctx, userId, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PrivacySettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, model.UpdatePrivacySettings) error); ok {
+		r1 = rf(ctx, userId, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, userId, update
+func (_m *UserService) UpdateProfile(ctx context.Context, userId publicid.ID, update model.UpdateProfile) (*model.UserProfile, error) {
+	ret := _m.Called(ctx, userId, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 *model.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.UpdateProfile) (*model.UserProfile, error)); ok {
+		return rf(ctx, userId, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, publicid.ID, model.UpdateProfile) *model.UserProfile); ok {
+		r0 = rf(ctx, userId, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, publicid.ID, model.UpdateProfile) error); ok {
+		r1 = rf(ctx, userId, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UserLogin provides a mock function with given fields: ctx, userData
 func (_m *UserService) UserLogin(ctx context.Context, userData model.UserSignIn) (*model.User, error) {
 	ret := _m.Called(ctx, userData)
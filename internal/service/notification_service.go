@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+const maxGroupActors = 3
+
+type NotificationService interface {
+	Notify(ctx context.Context, notifications ...model.Notification) error
+	GetNotifications(ctx context.Context, userId publicid.ID, params pagination.Params) (*model.NotificationList, error)
+	MarkNotificationsRead(ctx context.Context, userId publicid.ID, notificationIds []publicid.ID) error
+	MarkAllNotificationsRead(ctx context.Context, userId publicid.ID) error
+}
+
+type notificationServiceImpl struct {
+	repo repository.NotificationRepository
+	hub  infrastructure.EventHub
+}
+
+func NewNotificationService(repo repository.NotificationRepository, hub infrastructure.EventHub) NotificationService {
+	return &notificationServiceImpl{repo: repo, hub: hub}
+}
+
+// Notify store the notifications and push them to the recipients stream once
+// the transaction of ctx is committed, notification of a user about their own
+// action is skipped.
+func (n *notificationServiceImpl) Notify(ctx context.Context, notifications ...model.Notification) error {
+	toSend := []model.Notification{}
+	for _, notification := range notifications {
+		if notification.UserId == 0 || notification.UserId == notification.ActorId {
+			continue
+		}
+		toSend = append(toSend, notification)
+	}
+
+	if len(toSend) == 0 {
+		return nil
+	}
+
+	err := n.repo.CreateNotifications(ctx, toSend)
+	if err != nil {
+		return err
+	}
+
+	// inside a transaction the recipients must not see the notifications
+	// before they are committed, nor at all when it is rolled back
+	infrastructure.AfterCommit(ctx, func(ctx context.Context) {
+		for _, notification := range toSend {
+			err := n.hub.Publish(ctx, infrastructure.UserTopic(notification.UserId), "notification.created", notification)
+			if err != nil {
+				slog.ErrorContext(ctx, "cannot publish notification event", "error", err)
+			}
+		}
+	})
+
+	return nil
+}
+
+// GetNotifications return a page of the user notifications, the notifications
+// of the page about the same thing (e.g. likes of the same photo) are grouped
+// together. The grouping is done per page so the cursor stay a plain
+// notification cursor: the limit counts notifications, and the older
+// notifications of a group can come again as a group of the next page.
+func (n *notificationServiceImpl) GetNotifications(ctx context.Context, userId publicid.ID, params pagination.Params) (*model.NotificationList, error) {
+	notifications, err := n.repo.GetNotificationsByUserId(ctx, userId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadCount, err := n.repo.CountUnreadNotifications(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, nextCursor := pagination.Trim(notifications, params, model.NotificationView.SortKey)
+
+	notificationList := model.NotificationList{}
+	notificationList.Notifications = groupNotifications(notifications)
+	notificationList.UnreadCount = unreadCount
+	notificationList.NextCursor = nextCursor
+
+	return &notificationList, nil
+}
+
+func (n *notificationServiceImpl) MarkNotificationsRead(ctx context.Context, userId publicid.ID, notificationIds []publicid.ID) error {
+	return n.repo.MarkNotificationsRead(ctx, userId, notificationIds)
+}
+
+func (n *notificationServiceImpl) MarkAllNotificationsRead(ctx context.Context, userId publicid.ID) error {
+	return n.repo.MarkAllNotificationsRead(ctx, userId)
+}
+
+// groupNotifications merge the notifications with the same type, entity and
+// read state, notifications must be sorted from the newest.
+func groupNotifications(notifications []model.NotificationView) []model.NotificationGroup {
+	groups := []model.NotificationGroup{}
+	groupIndex := map[string]int{}
+	groupActors := map[string]map[publicid.ID]bool{}
+
+	for _, notification := range notifications {
+		isRead := notification.ReadAt != nil
+		key := fmt.Sprintf("%s:%s:%d:%t", notification.Type, notification.EntityType, notification.EntityId, isRead)
+
+		i, ok := groupIndex[key]
+		if !ok {
+			groups = append(groups, model.NotificationGroup{
+				Type:            notification.Type,
+				EntityType:      notification.EntityType,
+				EntityId:        notification.EntityId,
+				Actors:          []model.UserItem{},
+				NotificationIds: []publicid.ID{},
+				IsRead:          isRead,
+				LatestAt:        notification.CreatedAt,
+			})
+			i = len(groups) - 1
+			groupIndex[key] = i
+			groupActors[key] = map[publicid.ID]bool{}
+		}
+
+		groups[i].NotificationIds = append(groups[i].NotificationIds, notification.ID)
+		if !groupActors[key][notification.ActorId] {
+			groupActors[key][notification.ActorId] = true
+			groups[i].ActorCount++
+			if len(groups[i].Actors) < maxGroupActors {
+				groups[i].Actors = append(groups[i].Actors, notification.Actor)
+			}
+		}
+	}
+
+	for i := range groups {
+		groups[i].Message = notificationMessage(groups[i])
+	}
+
+	return groups
+}
+
+func notificationMessage(group model.NotificationGroup) string {
+	actor := "someone"
+	if len(group.Actors) > 0 && group.Actors[0].Username != "" {
+		actor = group.Actors[0].Username
+	}
+
+	switch {
+	case group.ActorCount == 2:
+		actor += " and 1 other"
+	case group.ActorCount > 2:
+		actor += fmt.Sprintf(" and %d others", group.ActorCount-1)
+	}
+
+	switch group.Type {
+	case model.NotificationTypeComment:
+		return fmt.Sprintf("%s commented on your %s", actor, group.EntityType)
+	case model.NotificationTypeReply:
+		return fmt.Sprintf("%s replied to your %s", actor, group.EntityType)
+	case model.NotificationTypeLike:
+		return fmt.Sprintf("%s liked your %s", actor, group.EntityType)
+	case model.NotificationTypeFollow:
+		return fmt.Sprintf("%s started following you", actor)
+	case model.NotificationTypeMention:
+		return fmt.Sprintf("%s mentioned you in a %s", actor, group.EntityType)
+	}
+
+	return fmt.Sprintf("%s interacted with your %s", actor, group.EntityType)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	infraMocks "github.com/zikri124/mygram-api/internal/infrastructure/mock"
+	"github.com/zikri124/mygram-api/internal/model"
+	repoMocks "github.com/zikri124/mygram-api/internal/repository/mocks"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestGroupNotifications(t *testing.T) {
+	now := time.Now()
+	readAt := now
+
+	like := func(id publicid.ID, actorId publicid.ID, username string, photoId publicid.ID) model.NotificationView {
+		return model.NotificationView{
+			ID:         id,
+			UserId:     1,
+			ActorId:    actorId,
+			Type:       model.NotificationTypeLike,
+			EntityType: "photo",
+			EntityId:   photoId,
+			CreatedAt:  now.Add(-time.Duration(id) * time.Minute),
+			Actor:      model.UserItem{ID: actorId, Username: username},
+		}
+	}
+
+	t.Run("group likes of the same photo", func(t *testing.T) {
+		notifications := []model.NotificationView{
+			like(1, 10, "alice", 100),
+			like(2, 11, "bob", 100),
+			like(3, 12, "carol", 200),
+			like(4, 13, "dave", 100),
+			like(5, 10, "alice", 100),
+		}
+
+		groups := groupNotifications(notifications)
+		assert.Len(t, groups, 2)
+		assert.Equal(t, "alice and 2 others liked your photo", groups[0].Message)
+		assert.Equal(t, 3, groups[0].ActorCount)
+		assert.Equal(t, []publicid.ID{1, 2, 4, 5}, groups[0].NotificationIds)
+		assert.Equal(t, notifications[0].CreatedAt, groups[0].LatestAt)
+		assert.Equal(t, "carol liked your photo", groups[1].Message)
+	})
+
+	t.Run("read and unread notifications are not grouped together", func(t *testing.T) {
+		read := like(2, 11, "bob", 100)
+		read.ReadAt = &readAt
+
+		groups := groupNotifications([]model.NotificationView{like(1, 10, "alice", 100), read})
+		assert.Len(t, groups, 2)
+		assert.False(t, groups[0].IsRead)
+		assert.True(t, groups[1].IsRead)
+	})
+}
+
+func TestGetNotifications(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	like := func(id publicid.ID, actorId publicid.ID, username string) model.NotificationView {
+		return model.NotificationView{
+			ID:         id,
+			UserId:     1,
+			ActorId:    actorId,
+			Type:       model.NotificationTypeLike,
+			EntityType: "photo",
+			EntityId:   100,
+			CreatedAt:  now.Add(-time.Duration(id) * time.Minute),
+			Actor:      model.UserItem{ID: actorId, Username: username},
+		}
+	}
+
+	t.Run("notifications are grouped per page", func(t *testing.T) {
+		repo := repoMocks.NewNotificationRepository(t)
+		notificationService := notificationServiceImpl{repo: repo}
+
+		// the repository fetch one more notification than the limit to know
+		// whether there is a next page
+		firstParams := pagination.Params{Limit: 2, Sort: pagination.SortNewest}
+		repo.On("GetNotificationsByUserId", ctx, publicid.ID(1), firstParams).
+			Return([]model.NotificationView{like(1, 10, "alice"), like(2, 11, "bob"), like(3, 12, "carol")}, nil)
+		repo.On("CountUnreadNotifications", ctx, publicid.ID(1)).Return(int64(3), nil)
+
+		firstPage, err := notificationService.GetNotifications(ctx, 1, firstParams)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, firstPage.NextCursor)
+		assert.Len(t, firstPage.Notifications, 1)
+		assert.Equal(t, "alice and 1 other liked your photo", firstPage.Notifications[0].Message)
+		assert.Equal(t, []publicid.ID{1, 2}, firstPage.Notifications[0].NotificationIds)
+
+		cursor, err := pagination.DecodeCursor(firstPage.NextCursor)
+		assert.Nil(t, err)
+		secondParams := pagination.Params{Limit: 2, Sort: pagination.SortNewest, Cursor: cursor}
+		repo.On("GetNotificationsByUserId", ctx, publicid.ID(1), secondParams).
+			Return([]model.NotificationView{like(3, 12, "carol")}, nil)
+
+		secondPage, err := notificationService.GetNotifications(ctx, 1, secondParams)
+		assert.Nil(t, err)
+		assert.Empty(t, secondPage.NextCursor)
+		assert.Len(t, secondPage.Notifications, 1)
+		assert.Equal(t, "carol liked your photo", secondPage.Notifications[0].Message)
+		assert.Equal(t, []publicid.ID{3}, secondPage.Notifications[0].NotificationIds)
+	})
+}
+
+func TestNotify(t *testing.T) {
+	ctx := context.Background()
+	like := model.Notification{UserId: 2, ActorId: 1, Type: model.NotificationTypeLike, EntityType: "photo", EntityId: 100}
+
+	newNotifyService := func(t *testing.T) (*notificationServiceImpl, infrastructure.TxManager, sqlmock.Sqlmock, *repoMocks.NotificationRepository, *infraMocks.EventHub) {
+		db, sqlMock, err := sqlmock.New()
+		assert.Nil(t, err)
+
+		gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+		assert.Nil(t, err)
+
+		postgresMock := infraMocks.NewGormPostgres(t)
+		postgresMock.On("GetConnection", ctx).Return(gormDB)
+
+		repo := repoMocks.NewNotificationRepository(t)
+		hub := infraMocks.NewEventHub(t)
+		return &notificationServiceImpl{repo: repo, hub: hub}, infrastructure.NewTxManager(postgresMock), sqlMock, repo, hub
+	}
+
+	t.Run("notifications are published once the transaction is committed", func(t *testing.T) {
+		notificationService, txManager, sqlMock, repo, hub := newNotifyService(t)
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		published := false
+		repo.On("CreateNotifications", mock.Anything, []model.Notification{like}).Return(nil)
+		hub.On("Publish", ctx, infrastructure.UserTopic(2), "notification.created", like).Run(func(args mock.Arguments) {
+			published = true
+		}).Return(nil)
+
+		err := txManager.WithTx(ctx, func(ctx context.Context) error {
+			err := notificationService.Notify(ctx, like)
+			assert.False(t, published)
+			return err
+		})
+		assert.Nil(t, err)
+		assert.True(t, published)
+		assert.Nil(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("notifications of a rolled back write are not published", func(t *testing.T) {
+		notificationService, txManager, sqlMock, repo, _ := newNotifyService(t)
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		repo.On("CreateNotifications", mock.Anything, []model.Notification{like}).Return(nil)
+
+		errWrite := errors.New("write failed")
+		err := txManager.WithTx(ctx, func(ctx context.Context) error {
+			err := notificationService.Notify(ctx, like)
+			if err != nil {
+				return err
+			}
+			return errWrite
+		})
+		assert.Equal(t, errWrite, err)
+		assert.Nil(t, sqlMock.ExpectationsWereMet())
+	})
+}
@@ -2,30 +2,43 @@ package service
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/zikri124/mygram-api/internal/infrastructure"
 	"github.com/zikri124/mygram-api/internal/model"
 	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/imaging"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 )
 
 type PhotoService interface {
 	PostPhoto(ctx context.Context, photo model.Photo) (*model.PhotoResCreate, error)
-	GetAllPhotosByUserId(ctx context.Context, userId uint32) ([]model.PhotoView, error)
-	GetPhotoById(ctx context.Context, photoId uint32) (*model.PhotoView, error)
+	UploadPhoto(ctx context.Context, userId publicid.ID, upload model.PhotoUpload, image []byte) (*model.PhotoResCreate, error)
+	GetAllPhotosByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) (*pagination.Page, error)
+	GetPhotoById(ctx context.Context, photoId publicid.ID) (*model.PhotoView, error)
 	UpdatePhoto(ctx context.Context, photo model.Photo) (*model.PhotoResUpdate, error)
-	DeletePhoto(ctx context.Context, photoId uint32) error
+	DeletePhoto(ctx context.Context, photoId publicid.ID) error
 }
 
 type photoServiceImpl struct {
-	repo repository.PhotoRepository
+	repo       repository.PhotoRepository
+	tagRepo    repository.TagRepository
+	mentionSvc MentionService
+	storage    infrastructure.FileStorage
+	webhookSvc WebhookService
+	txManager  infrastructure.TxManager
 }
 
-func NewPhotoService(repo repository.PhotoRepository) PhotoService {
-	return &photoServiceImpl{repo: repo}
+func NewPhotoService(repo repository.PhotoRepository, tagRepo repository.TagRepository, mentionSvc MentionService, storage infrastructure.FileStorage, webhookSvc WebhookService, txManager infrastructure.TxManager) PhotoService {
+	return &photoServiceImpl{repo: repo, tagRepo: tagRepo, mentionSvc: mentionSvc, storage: storage, webhookSvc: webhookSvc, txManager: txManager}
 }
 
 func (p *photoServiceImpl) PostPhoto(ctx context.Context, photo model.Photo) (*model.PhotoResCreate, error) {
-	err := p.repo.CreatePhoto(ctx, &photo)
+	mentions, err := p.savePhoto(ctx, &photo, p.repo.CreatePhoto)
 	if err != nil {
 		return nil, err
 	}
@@ -36,21 +49,82 @@ func (p *photoServiceImpl) PostPhoto(ctx context.Context, photo model.Photo) (*m
 	photoRes.PhotoUrl = photo.PhotoUrl
 	photoRes.Title = photo.Title
 	photoRes.UserId = photo.UserId
+	photoRes.Mentions = mentions
 	photoRes.CreatedAt = time.Now()
 
+	err = p.webhookSvc.Dispatch(ctx, model.WebhookEventPhotoCreated, []publicid.ID{photo.UserId}, photoRes)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot dispatch photo webhook", "error", err)
+	}
+
 	return &photoRes, nil
 }
 
-func (p *photoServiceImpl) GetAllPhotosByUserId(ctx context.Context, userId uint32) ([]model.PhotoView, error) {
-	photos, err := p.repo.GetAllPhotosByUserId(ctx, userId)
+func (p *photoServiceImpl) UploadPhoto(ctx context.Context, userId publicid.ID, upload model.PhotoUpload, image []byte) (*model.PhotoResCreate, error) {
+	cleanImage, err := imaging.Sanitize(image)
+	if err != nil {
+		return nil, err
+	}
+
+	photoUrl, err := p.storage.Save(ctx, uuid.NewString()+cleanImage.Extension, cleanImage.Data)
 	if err != nil {
 		return nil, err
 	}
 
-	return photos, nil
+	photo := model.Photo{}
+	photo.UserId = userId
+	photo.Title = upload.Title
+	photo.Caption = upload.Caption
+	photo.PhotoUrl = photoUrl
+
+	if upload.KeepCameraInfo {
+		photo.Camera = model.PhotoCamera{
+			Make:         cleanImage.Camera.Make,
+			Model:        cleanImage.Camera.Model,
+			LensModel:    cleanImage.Camera.LensModel,
+			ExposureTime: cleanImage.Camera.ExposureTime,
+			FNumber:      cleanImage.Camera.FNumber,
+			FocalLength:  cleanImage.Camera.FocalLength,
+			ISO:          cleanImage.Camera.ISO,
+		}
+	}
+
+	mentions, err := p.savePhoto(ctx, &photo, p.repo.CreatePhoto)
+	if err != nil {
+		p.storage.Delete(ctx, photoUrl)
+		return nil, err
+	}
+
+	photoRes := model.PhotoResCreate{}
+	photoRes.ID = photo.ID
+	photoRes.Caption = photo.Caption
+	photoRes.PhotoUrl = photo.PhotoUrl
+	photoRes.Title = photo.Title
+	photoRes.UserId = photo.UserId
+	photoRes.Camera = photo.Camera
+	photoRes.Mentions = mentions
+	photoRes.CreatedAt = photo.CreatedAt
+
+	err = p.webhookSvc.Dispatch(ctx, model.WebhookEventPhotoCreated, []publicid.ID{photo.UserId}, photoRes)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot dispatch photo webhook", "error", err)
+	}
+
+	return &photoRes, nil
+}
+
+func (p *photoServiceImpl) GetAllPhotosByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) (*pagination.Page, error) {
+	photos, err := p.repo.GetAllPhotosByUserId(ctx, userId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.NewPage(photos, params, model.PhotoView.SortKey)
+
+	return &page, nil
 }
 
-func (p *photoServiceImpl) GetPhotoById(ctx context.Context, photoId uint32) (*model.PhotoView, error) {
+func (p *photoServiceImpl) GetPhotoById(ctx context.Context, photoId publicid.ID) (*model.PhotoView, error) {
 	photo, err := p.repo.GetPhotoById(ctx, photoId)
 	if err != nil {
 		return nil, err
@@ -60,7 +134,7 @@ func (p *photoServiceImpl) GetPhotoById(ctx context.Context, photoId uint32) (*m
 }
 
 func (p *photoServiceImpl) UpdatePhoto(ctx context.Context, photo model.Photo) (*model.PhotoResUpdate, error) {
-	err := p.repo.UpdatePhoto(ctx, &photo)
+	mentions, err := p.savePhoto(ctx, &photo, p.repo.UpdatePhoto)
 	if err != nil {
 		return nil, err
 	}
@@ -71,13 +145,40 @@ func (p *photoServiceImpl) UpdatePhoto(ctx context.Context, photo model.Photo) (
 	photoRes.PhotoUrl = photo.PhotoUrl
 	photoRes.Title = photo.Title
 	photoRes.UserId = photo.UserId
+	photoRes.Mentions = mentions
 	photoRes.UpdatedAt = photo.UpdatedAt
 
 	return &photoRes, nil
 }
 
-func (p *photoServiceImpl) DeletePhoto(ctx context.Context, photoId uint32) error {
+func (p *photoServiceImpl) DeletePhoto(ctx context.Context, photoId publicid.ID) error {
 	err := p.repo.DeletePhoto(ctx, photoId)
 
 	return err
 }
+
+// savePhoto write the photo with write (create or update) and sync the tags
+// and mentions of its caption in one transaction.
+func (p *photoServiceImpl) savePhoto(ctx context.Context, photo *model.Photo, write func(ctx context.Context, photo *model.Photo) error) ([]model.MentionView, error) {
+	var mentions []model.MentionView
+
+	err := p.txManager.WithTx(ctx, func(ctx context.Context) error {
+		err := write(ctx, photo)
+		if err != nil {
+			return err
+		}
+
+		err = p.tagRepo.SyncPhotoTags(ctx, photo.ID, helper.ExtractHashtags(photo.Caption))
+		if err != nil {
+			return err
+		}
+
+		mentions, err = p.mentionSvc.SyncMentions(ctx, model.MentionSourcePhoto, photo.ID, photo.UserId, photo.Caption)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mentions, nil
+}
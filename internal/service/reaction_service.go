@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+var ErrInvalidReactionEmoji = errors.New("emoji must be one of " + strings.Join(model.ReactionEmojis, " "))
+
+type ReactionService interface {
+	GetReactionSummaries(ctx context.Context, targetType string, targetIds []publicid.ID, viewerId publicid.ID) (map[publicid.ID]model.ReactionSummary, error)
+	React(ctx context.Context, target model.ReactionTarget, userId publicid.ID, emoji string) (*model.ReactionSummary, error)
+	RemoveReaction(ctx context.Context, target model.ReactionTarget, userId publicid.ID) (*model.ReactionSummary, error)
+}
+
+type reactionServiceImpl struct {
+	repo     repository.ReactionRepository
+	notifSvc NotificationService
+	hub      infrastructure.EventHub
+}
+
+func NewReactionService(repo repository.ReactionRepository, notifSvc NotificationService, hub infrastructure.EventHub) ReactionService {
+	return &reactionServiceImpl{repo: repo, notifSvc: notifSvc, hub: hub}
+}
+
+// GetReactionSummaries return the reaction counts of every target and the
+// reaction of viewerId on it, targets without reaction have empty counts.
+func (r *reactionServiceImpl) GetReactionSummaries(ctx context.Context, targetType string, targetIds []publicid.ID, viewerId publicid.ID) (map[publicid.ID]model.ReactionSummary, error) {
+	summaries := map[publicid.ID]model.ReactionSummary{}
+	if len(targetIds) == 0 {
+		return summaries, nil
+	}
+
+	for _, targetId := range targetIds {
+		summaries[targetId] = model.ReactionSummary{TargetType: targetType, TargetId: targetId, Reactions: []model.ReactionCount{}}
+	}
+
+	counts, err := r.repo.GetReactionCounts(ctx, targetType, targetIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, count := range counts {
+		summary := summaries[count.TargetId]
+		summary.Reactions = append(summary.Reactions, model.ReactionCount{Emoji: count.Emoji, Count: count.Count})
+		summaries[count.TargetId] = summary
+	}
+
+	reactions, err := r.repo.GetUserReactions(ctx, targetType, targetIds, viewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		emoji := reaction.Emoji
+		summary := summaries[reaction.TargetId]
+		summary.MyReaction = &emoji
+		summaries[reaction.TargetId] = summary
+	}
+
+	return summaries, nil
+}
+
+// React set the reaction of the user on the target, the owner of the target
+// is only notified the first time the user reacts. An emoji outside of
+// model.ReactionEmojis return ErrInvalidReactionEmoji.
+func (r *reactionServiceImpl) React(ctx context.Context, target model.ReactionTarget, userId publicid.ID, emoji string) (*model.ReactionSummary, error) {
+	if !model.IsValidReactionEmoji(emoji) {
+		return nil, ErrInvalidReactionEmoji
+	}
+
+	current, err := r.repo.GetUserReaction(ctx, target.Type, target.Id, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := model.Reaction{}
+	reaction.TargetType = target.Type
+	reaction.TargetId = target.Id
+	reaction.UserId = userId
+	reaction.Emoji = emoji
+	reaction.UpdatedAt = time.Now()
+
+	err = r.repo.UpsertReaction(ctx, &reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.UserId == 0 {
+		err = r.notifSvc.Notify(ctx, model.Notification{
+			UserId:     target.OwnerId,
+			ActorId:    userId,
+			Type:       model.NotificationTypeLike,
+			EntityType: target.Type,
+			EntityId:   target.Id,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r.reactionChanged(ctx, target, userId)
+}
+
+func (r *reactionServiceImpl) RemoveReaction(ctx context.Context, target model.ReactionTarget, userId publicid.ID) (*model.ReactionSummary, error) {
+	err := r.repo.DeleteReaction(ctx, target.Type, target.Id, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.reactionChanged(ctx, target, userId)
+}
+
+// reactionChanged return the new summary of the target for userId and push
+// the new counts to the viewers of the photo.
+func (r *reactionServiceImpl) reactionChanged(ctx context.Context, target model.ReactionTarget, userId publicid.ID) (*model.ReactionSummary, error) {
+	summaries, err := r.GetReactionSummaries(ctx, target.Type, []publicid.ID{target.Id}, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := summaries[target.Id]
+
+	event := summary
+	event.MyReaction = nil
+	err = r.hub.Publish(ctx, infrastructure.PhotoTopic(target.PhotoId), "reaction.updated", event)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot publish reaction event", "error", err)
+	}
+
+	return &summary, nil
+}
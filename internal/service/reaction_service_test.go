@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	infraMocks "github.com/zikri124/mygram-api/internal/infrastructure/mock"
+	"github.com/zikri124/mygram-api/internal/model"
+	repoMocks "github.com/zikri124/mygram-api/internal/repository/mocks"
+	"github.com/zikri124/mygram-api/internal/service/mocks"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+type reactionServiceMocks struct {
+	repo     *repoMocks.ReactionRepository
+	notifSvc *mocks.NotificationService
+	hub      *infraMocks.EventHub
+}
+
+func newReactionService(t *testing.T) (*reactionServiceImpl, reactionServiceMocks) {
+	m := reactionServiceMocks{
+		repo:     repoMocks.NewReactionRepository(t),
+		notifSvc: mocks.NewNotificationService(t),
+		hub:      infraMocks.NewEventHub(t),
+	}
+
+	return &reactionServiceImpl{repo: m.repo, notifSvc: m.notifSvc, hub: m.hub}, m
+}
+
+func TestReact(t *testing.T) {
+	ctx := context.Background()
+	target := model.ReactionTarget{Type: model.ReactionTargetComment, Id: 20, OwnerId: 2, PhotoId: 100}
+	targetIds := []publicid.ID{20}
+
+	t.Run("emoji outside of the reactions is refused", func(t *testing.T) {
+		reactionService, _ := newReactionService(t)
+
+		summary, err := reactionService.React(ctx, target, 1, "🍕")
+		assert.ErrorIs(t, err, ErrInvalidReactionEmoji)
+		assert.Nil(t, summary)
+	})
+
+	t.Run("first reaction notify the owner of the target", func(t *testing.T) {
+		reactionService, m := newReactionService(t)
+
+		m.repo.On("GetUserReaction", ctx, model.ReactionTargetComment, publicid.ID(20), publicid.ID(1)).Return(&model.Reaction{}, nil)
+		m.repo.On("UpsertReaction", ctx, mock.MatchedBy(func(reaction *model.Reaction) bool {
+			return reaction.TargetId == 20 && reaction.UserId == 1 && reaction.Emoji == "👍"
+		})).Return(nil)
+		m.notifSvc.On("Notify", ctx, model.Notification{
+			UserId:     2,
+			ActorId:    1,
+			Type:       model.NotificationTypeLike,
+			EntityType: model.ReactionTargetComment,
+			EntityId:   20,
+		}).Return(nil)
+		m.repo.On("GetReactionCounts", ctx, model.ReactionTargetComment, targetIds).Return([]model.TargetReactionCount{{TargetId: 20, Emoji: "👍", Count: 1}}, nil)
+		m.repo.On("GetUserReactions", ctx, model.ReactionTargetComment, targetIds, publicid.ID(1)).Return([]model.Reaction{{TargetId: 20, UserId: 1, Emoji: "👍"}}, nil)
+		m.hub.On("Publish", ctx, infrastructure.PhotoTopic(100), "reaction.updated", mock.MatchedBy(func(event model.ReactionSummary) bool {
+			// the event is sent to every viewer, it must not carry the
+			// reaction of the user
+			return event.MyReaction == nil && len(event.Reactions) == 1
+		})).Return(nil)
+
+		summary, err := reactionService.React(ctx, target, 1, "👍")
+		assert.Nil(t, err)
+		assert.Equal(t, []model.ReactionCount{{Emoji: "👍", Count: 1}}, summary.Reactions)
+		assert.Equal(t, "👍", *summary.MyReaction)
+	})
+
+	t.Run("changing the reaction replace it without notifying again", func(t *testing.T) {
+		reactionService, m := newReactionService(t)
+
+		m.repo.On("GetUserReaction", ctx, model.ReactionTargetComment, publicid.ID(20), publicid.ID(1)).Return(&model.Reaction{TargetId: 20, UserId: 1, Emoji: "👍"}, nil)
+		m.repo.On("UpsertReaction", ctx, mock.MatchedBy(func(reaction *model.Reaction) bool {
+			return reaction.UserId == 1 && reaction.Emoji == "🔥"
+		})).Return(nil)
+		m.repo.On("GetReactionCounts", ctx, model.ReactionTargetComment, targetIds).Return([]model.TargetReactionCount{{TargetId: 20, Emoji: "🔥", Count: 1}}, nil)
+		m.repo.On("GetUserReactions", ctx, model.ReactionTargetComment, targetIds, publicid.ID(1)).Return([]model.Reaction{{TargetId: 20, UserId: 1, Emoji: "🔥"}}, nil)
+		m.hub.On("Publish", ctx, infrastructure.PhotoTopic(100), "reaction.updated", mock.Anything).Return(nil)
+
+		summary, err := reactionService.React(ctx, target, 1, "🔥")
+		assert.Nil(t, err)
+		assert.Equal(t, []model.ReactionCount{{Emoji: "🔥", Count: 1}}, summary.Reactions)
+		assert.Equal(t, "🔥", *summary.MyReaction)
+	})
+}
+
+func TestGetReactionSummaries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("counts and own reaction of every target", func(t *testing.T) {
+		reactionService, m := newReactionService(t)
+		targetIds := []publicid.ID{10, 11, 12}
+
+		m.repo.On("GetReactionCounts", ctx, model.ReactionTargetPhoto, targetIds).Return([]model.TargetReactionCount{
+			{TargetId: 10, Emoji: "❤️", Count: 3},
+			{TargetId: 10, Emoji: "😂", Count: 1},
+			{TargetId: 11, Emoji: "🔥", Count: 2},
+		}, nil)
+		m.repo.On("GetUserReactions", ctx, model.ReactionTargetPhoto, targetIds, publicid.ID(1)).Return([]model.Reaction{
+			{TargetId: 11, UserId: 1, Emoji: "🔥"},
+		}, nil)
+
+		summaries, err := reactionService.GetReactionSummaries(ctx, model.ReactionTargetPhoto, targetIds, 1)
+		assert.Nil(t, err)
+		assert.Len(t, summaries, 3)
+
+		assert.Equal(t, []model.ReactionCount{{Emoji: "❤️", Count: 3}, {Emoji: "😂", Count: 1}}, summaries[10].Reactions)
+		assert.Nil(t, summaries[10].MyReaction)
+
+		assert.Equal(t, []model.ReactionCount{{Emoji: "🔥", Count: 2}}, summaries[11].Reactions)
+		assert.Equal(t, "🔥", *summaries[11].MyReaction)
+
+		// targets without reaction have empty counts
+		assert.Equal(t, model.ReactionSummary{TargetType: model.ReactionTargetPhoto, TargetId: 12, Reactions: []model.ReactionCount{}}, summaries[12])
+	})
+
+	t.Run("no target does not query the reactions", func(t *testing.T) {
+		reactionService, _ := newReactionService(t)
+
+		summaries, err := reactionService.GetReactionSummaries(ctx, model.ReactionTargetPhoto, []publicid.ID{}, 1)
+		assert.Nil(t, err)
+		assert.Empty(t, summaries)
+	})
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/helper"
+)
+
+type SearchService interface {
+	Search(ctx context.Context, query string, searchTypes []string, limit int) (*model.SearchRes, error)
+}
+
+type searchServiceImpl struct {
+	repo repository.SearchRepository
+}
+
+func NewSearchService(repo repository.SearchRepository) SearchService {
+	return &searchServiceImpl{repo: repo}
+}
+
+// Search return up to limit results of every requested type, every word of
+// the query is matched as a prefix so a partial word already find results.
+func (s *searchServiceImpl) Search(ctx context.Context, query string, searchTypes []string, limit int) (*model.SearchRes, error) {
+	searchRes := model.SearchRes{}
+	searchRes.Query = query
+	searchRes.Users = []model.UserSearchItem{}
+	searchRes.Photos = []model.PhotoSearchItem{}
+	searchRes.Comments = []model.CommentSearchItem{}
+
+	tsQuery := helper.PrefixTsQuery(query)
+	if tsQuery == "" {
+		return &searchRes, nil
+	}
+
+	var err error
+	for _, searchType := range searchTypes {
+		switch searchType {
+		case model.SearchTypeUser:
+			username := strings.TrimPrefix(strings.TrimSpace(query), "@")
+			searchRes.Users, err = s.repo.SearchUsers(ctx, tsQuery, username, limit)
+		case model.SearchTypePhoto:
+			searchRes.Photos, err = s.repo.SearchPhotos(ctx, tsQuery, limit)
+		case model.SearchTypeComment:
+			searchRes.Comments, err = s.repo.SearchComments(ctx, tsQuery, limit)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &searchRes, nil
+}
@@ -5,14 +5,16 @@ import (
 
 	"github.com/zikri124/mygram-api/internal/model"
 	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 )
 
 type SocialMediaService interface {
-	PostSocial(ctx context.Context, userId uint32, social model.NewSocialMedia) (*model.CreateSocialMediaRes, error)
-	GetAllSocialMediasByUserId(ctx context.Context, userId uint32) ([]model.SocialMediaView, error)
-	GetSocialById(ctx context.Context, socialId uint32) (*model.SocialMediaView, error)
+	PostSocial(ctx context.Context, userId publicid.ID, social model.NewSocialMedia) (*model.CreateSocialMediaRes, error)
+	GetAllSocialMediasByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) (*pagination.Page, error)
+	GetSocialById(ctx context.Context, socialId publicid.ID) (*model.SocialMediaView, error)
 	UpdateSocial(ctx context.Context, social model.SocialMedia) (*model.UpdateSocialMediaRes, error)
-	DeleteSocial(ctx context.Context, socialId uint32) error
+	DeleteSocial(ctx context.Context, socialId publicid.ID) error
 }
 
 type socialMediaServiceImpl struct {
@@ -23,7 +25,7 @@ func NewSocialMediaService(repo repository.SocialMediaRepository) SocialMediaSer
 	return &socialMediaServiceImpl{repo: repo}
 }
 
-func (s *socialMediaServiceImpl) PostSocial(ctx context.Context, userId uint32, newSocial model.NewSocialMedia) (*model.CreateSocialMediaRes, error) {
+func (s *socialMediaServiceImpl) PostSocial(ctx context.Context, userId publicid.ID, newSocial model.NewSocialMedia) (*model.CreateSocialMediaRes, error) {
 	social := model.SocialMedia{}
 	social.Name = newSocial.Name
 	social.SocialMediaUrl = newSocial.SocialMediaUrl
@@ -44,16 +46,18 @@ func (s *socialMediaServiceImpl) PostSocial(ctx context.Context, userId uint32,
 	return &socialMediaRes, nil
 }
 
-func (s *socialMediaServiceImpl) GetAllSocialMediasByUserId(ctx context.Context, userId uint32) ([]model.SocialMediaView, error) {
-	socials, err := s.repo.GetAllSocialMediasByUserId(ctx, userId)
+func (s *socialMediaServiceImpl) GetAllSocialMediasByUserId(ctx context.Context, userId publicid.ID, params pagination.Params) (*pagination.Page, error) {
+	socials, err := s.repo.GetAllSocialMediasByUserId(ctx, userId, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return socials, nil
+	page := pagination.NewPage(socials, params, model.SocialMediaView.SortKey)
+
+	return &page, nil
 }
 
-func (s *socialMediaServiceImpl) GetSocialById(ctx context.Context, socialId uint32) (*model.SocialMediaView, error) {
+func (s *socialMediaServiceImpl) GetSocialById(ctx context.Context, socialId publicid.ID) (*model.SocialMediaView, error) {
 	social, err := s.repo.GetSocialById(ctx, socialId)
 	if err != nil {
 		return nil, err
@@ -79,6 +83,6 @@ func (s *socialMediaServiceImpl) UpdateSocial(ctx context.Context, social model.
 	return &socialRes, nil
 }
 
-func (s *socialMediaServiceImpl) DeleteSocial(ctx context.Context, socialId uint32) error {
+func (s *socialMediaServiceImpl) DeleteSocial(ctx context.Context, socialId publicid.ID) error {
 	return s.repo.DeleteSocial(ctx, socialId)
 }
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+)
+
+type TagService interface {
+	GetPhotosByTag(ctx context.Context, tag string, params pagination.Params) (*model.TagPhotosRes, error)
+	GetTrendingTags(ctx context.Context, window time.Duration, limit int) ([]model.TagView, error)
+}
+
+type tagServiceImpl struct {
+	repo repository.TagRepository
+}
+
+func NewTagService(repo repository.TagRepository) TagService {
+	return &tagServiceImpl{repo: repo}
+}
+
+func (t *tagServiceImpl) GetPhotosByTag(ctx context.Context, tag string, params pagination.Params) (*model.TagPhotosRes, error) {
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+
+	count, err := t.repo.CountPhotosByTag(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	photos, err := t.repo.GetPhotosByTag(ctx, tag, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tagPhotos := model.TagPhotosRes{}
+	tagPhotos.Tag = tag
+	tagPhotos.PhotoCount = count
+	tagPhotos.Page = pagination.NewPage(photos, params, model.PhotoView.SortKey)
+
+	return &tagPhotos, nil
+}
+
+func (t *tagServiceImpl) GetTrendingTags(ctx context.Context, window time.Duration, limit int) ([]model.TagView, error) {
+	tags, err := t.repo.GetTrendingTags(ctx, time.Now().Add(-window), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
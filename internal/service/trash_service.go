@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+const trashPurgeInterval = time.Hour
+
+var ErrTrashPhotoDeleted = errors.New("the photo of the comment is deleted, restore the photo first")
+
+type TrashConfig struct {
+	RetentionDays int `yaml:"retention_days" env:"TRASH_RETENTION_DAYS"`
+}
+
+func (trashConfig TrashConfig) Retention() time.Duration {
+	return time.Duration(trashConfig.RetentionDays) * 24 * time.Hour
+}
+
+type TrashService interface {
+	GetTrash(ctx context.Context, userId publicid.ID) ([]model.TrashItem, error)
+	GetDeletedItem(ctx context.Context, itemType string, itemId publicid.ID) (*model.DeletedItem, error)
+	Restore(ctx context.Context, itemType string, item model.DeletedItem) error
+	RunPurgeWorker(ctx context.Context, stop <-chan struct{})
+}
+
+type trashServiceImpl struct {
+	repo    repository.TrashRepository
+	storage infrastructure.FileStorage
+	config  TrashConfig
+}
+
+func NewTrashService(repo repository.TrashRepository, storage infrastructure.FileStorage, config TrashConfig) TrashService {
+	return &trashServiceImpl{repo: repo, storage: storage, config: config}
+}
+
+func (t *trashServiceImpl) GetTrash(ctx context.Context, userId publicid.ID) ([]model.TrashItem, error) {
+	items, err := t.repo.GetTrashByUserId(ctx, userId, time.Now().Add(-t.config.Retention()))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].PurgeAt = items[i].DeletedAt.Add(t.config.Retention())
+	}
+
+	return items, nil
+}
+
+// GetDeletedItem return the deleted item of the type, the ID is 0 when it
+// does not exist, is not deleted or is past the retention.
+func (t *trashServiceImpl) GetDeletedItem(ctx context.Context, itemType string, itemId publicid.ID) (*model.DeletedItem, error) {
+	var item *model.DeletedItem
+	var err error
+
+	switch itemType {
+	case model.TrashTypePhoto:
+		item, err = t.repo.GetDeletedPhoto(ctx, itemId)
+	case model.TrashTypeComment:
+		item, err = t.repo.GetDeletedComment(ctx, itemId)
+	case model.TrashTypeSocialMedia:
+		item, err = t.repo.GetDeletedSocialMedia(ctx, itemId)
+	default:
+		return nil, errors.New("unknown trash item type " + itemType)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if item.ID != 0 && item.DeletedAt.Add(t.config.Retention()).Before(time.Now()) {
+		return &model.DeletedItem{}, nil
+	}
+
+	return item, nil
+}
+
+// Restore the item, a photo is restored with the comments deleted with it and
+// a comment cannot be restored while its photo is deleted.
+func (t *trashServiceImpl) Restore(ctx context.Context, itemType string, item model.DeletedItem) error {
+	switch itemType {
+	case model.TrashTypePhoto:
+		return t.repo.RestorePhoto(ctx, item)
+	case model.TrashTypeComment:
+		isPhotoDeleted, err := t.repo.IsPhotoDeleted(ctx, item.PhotoId)
+		if err != nil {
+			return err
+		}
+		if isPhotoDeleted {
+			return ErrTrashPhotoDeleted
+		}
+		return t.repo.RestoreComment(ctx, item.ID)
+	case model.TrashTypeSocialMedia:
+		return t.repo.RestoreSocialMedia(ctx, item.ID)
+	}
+
+	return errors.New("unknown trash item type " + itemType)
+}
+
+// RunPurgeWorker permanently delete the items past the retention until stop
+// is closed, a running purge is only aborted by ctx.
+func (t *trashServiceImpl) RunPurgeWorker(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			photoUrls, err := t.repo.PurgeTrash(ctx, time.Now().Add(-t.config.Retention()))
+			if err != nil {
+				slog.ErrorContext(ctx, "cannot purge trash", "error", err)
+				continue
+			}
+
+			for _, photoUrl := range photoUrls {
+				err = t.storage.Delete(ctx, photoUrl)
+				if err != nil && !errors.Is(err, infrastructure.ErrFileNotInStorage) {
+					slog.ErrorContext(ctx, "cannot delete file of purged photo", "error", err)
+				}
+			}
+		}
+	}
+}
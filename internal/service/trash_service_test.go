@@ -0,0 +1,13 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashConfigRetention(t *testing.T) {
+	assert.Equal(t, 30*24*time.Hour, TrashConfig{RetentionDays: 30}.Retention())
+	assert.Equal(t, 7*24*time.Hour, TrashConfig{RetentionDays: 7}.Retention())
+}
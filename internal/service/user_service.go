@@ -4,32 +4,70 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/zikri124/mygram-api/internal/infrastructure"
 	"github.com/zikri124/mygram-api/internal/model"
 	"github.com/zikri124/mygram-api/internal/repository"
 	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/imaging"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm"
+)
+
+const (
+	accountDeletionGracePeriod = 30 * 24 * time.Hour
+	accountPurgeInterval       = time.Hour
+	accountPurgeBatchSize      = 20
+)
+
+var (
+	ErrUsernameReserved = errors.New("username is reserved")
+	ErrUsernameTaken    = repository.ErrUsernameTaken
 )
 
 type UserService interface {
-	GetUserById(ctx context.Context, userId uint32) (*model.UserView, error)
+	GetUserById(ctx context.Context, userId publicid.ID) (*model.UserView, error)
+	GetPublicUserById(ctx context.Context, userId publicid.ID, viewer model.Viewer) (*model.UserPublicView, error)
+	GetUserByUsername(ctx context.Context, username string, viewer model.Viewer) (*model.UserPublicView, error)
+	GetUsernameSuggestions(ctx context.Context, prefix string, limit int) ([]model.UserSuggestion, error)
+	GetUserProfile(ctx context.Context, userId publicid.ID, viewer model.Viewer) (*model.UserProfile, error)
+	GetPrivacySettings(ctx context.Context, userId publicid.ID) (*model.PrivacySettings, error)
+	UpdatePrivacySettings(ctx context.Context, userId publicid.ID, update model.UpdatePrivacySettings) (*model.PrivacySettings, error)
+	UpdateProfile(ctx context.Context, userId publicid.ID, update model.UpdateProfile) (*model.UserProfile, error)
+	UpdateAvatar(ctx context.Context, userId publicid.ID, image []byte) (*model.UserProfile, error)
+	RemoveAvatar(ctx context.Context, userId publicid.ID) (*model.UserProfile, error)
 	UserRegister(ctx context.Context, userRegData model.UserSignUp) (*model.UserView, error)
 	CheckIsAValidAge(dobStr string) (bool, error)
 	UserLogin(ctx context.Context, userData model.UserSignIn) (*model.User, error)
 	GenerateAccessToken(ctx context.Context, user model.User) (token string, err error)
 	EditUser(ctx context.Context, userData model.User) (*model.UserView, error)
-	DeleteUser(ctx context.Context, userId uint32) (err error)
+	DeleteUser(ctx context.Context, userId publicid.ID) (err error)
+	RunPurgeWorker(ctx context.Context, stop <-chan struct{})
+}
+
+type JwtConfig struct {
+	Secret string `yaml:"secret" env:"JWT_SECRET"`
+	// TTL is how long an access token is valid.
+	TTL time.Duration `yaml:"ttl" env:"JWT_TTL"`
 }
 
 type userServiceImpl struct {
-	repo repository.UserRepository
+	repo       repository.UserRepository
+	webhookSvc WebhookService
+	socialSvc  SocialMediaService
+	storage    infrastructure.FileStorage
+	jwtConfig  JwtConfig
 }
 
-func NewUserService(repo repository.UserRepository) UserService {
-	return &userServiceImpl{repo: repo}
+func NewUserService(repo repository.UserRepository, webhookSvc WebhookService, socialSvc SocialMediaService, storage infrastructure.FileStorage, jwtConfig JwtConfig) UserService {
+	return &userServiceImpl{repo: repo, webhookSvc: webhookSvc, socialSvc: socialSvc, storage: storage, jwtConfig: jwtConfig}
 }
 
-func (u *userServiceImpl) GetUserById(ctx context.Context, userId uint32) (*model.UserView, error) {
+func (u *userServiceImpl) GetUserById(ctx context.Context, userId publicid.ID) (*model.UserView, error) {
 	user, err := u.repo.GetUserById(ctx, userId)
 	if err != nil {
 		return nil, err
@@ -37,15 +75,210 @@ func (u *userServiceImpl) GetUserById(ctx context.Context, userId uint32) (*mode
 
 	age := helper.CountAge(user.DOB)
 
-	userView := model.UserView{ID: user.ID, Username: user.Username, Email: user.Email, Age: age}
+	userView := model.UserView{ID: user.ID, Username: user.Username, Email: user.Email, Age: age, Role: user.Role}
 
 	return &userView, nil
 }
 
+func (u *userServiceImpl) GetPublicUserById(ctx context.Context, userId publicid.ID, viewer model.Viewer) (*model.UserPublicView, error) {
+	user, err := u.repo.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return publicUserView(user, viewer), nil
+}
+
+func (u *userServiceImpl) GetUserByUsername(ctx context.Context, username string, viewer model.Viewer) (*model.UserPublicView, error) {
+	user, err := u.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return publicUserView(user, viewer), nil
+}
+
+func (u *userServiceImpl) GetUsernameSuggestions(ctx context.Context, prefix string, limit int) ([]model.UserSuggestion, error) {
+	return u.repo.GetUsernameSuggestions(ctx, prefix, limit)
+}
+
+// GetUserProfile return the profile of the user with every social media link
+// of the user, the fields the user made private are empty unless the viewer
+// can see them. The ID is 0 when the user does not exist.
+func (u *userServiceImpl) GetUserProfile(ctx context.Context, userId publicid.ID, viewer model.Viewer) (*model.UserProfile, error) {
+	profile, err := u.repo.GetUserProfile(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.SocialMedias = []model.SocialMediaView{}
+	if profile.ID == 0 {
+		return &profile, nil
+	}
+
+	if !viewer.CanSeePrivate(userId) {
+		if !profile.Privacy.ShowBio {
+			profile.Bio = ""
+		}
+		if !profile.Privacy.ShowWebsite {
+			profile.Website = ""
+		}
+		if !profile.Privacy.ShowPronouns {
+			profile.Pronouns = ""
+		}
+		if !profile.Privacy.ShowSocialMedias {
+			return &profile, nil
+		}
+	}
+
+	socials, err := u.socialSvc.GetAllSocialMediasByUserId(ctx, userId, pagination.Params{Limit: pagination.MaxLimit, Sort: pagination.SortOldest})
+	if err != nil {
+		return nil, err
+	}
+	profile.SocialMedias = socials.Data.([]model.SocialMediaView)
+
+	return &profile, nil
+}
+
+func (u *userServiceImpl) UpdateProfile(ctx context.Context, userId publicid.ID, update model.UpdateProfile) (*model.UserProfile, error) {
+	user, err := u.repo.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.DisplayName != nil {
+		user.DisplayName = *update.DisplayName
+	}
+	if update.Bio != nil {
+		user.Bio = *update.Bio
+	}
+	if update.Website != nil {
+		user.Website = *update.Website
+	}
+	if update.Pronouns != nil {
+		user.Pronouns = *update.Pronouns
+	}
+	user.UpdatedAt = time.Now()
+
+	err = u.repo.UpdateProfile(ctx, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetUserProfile(ctx, userId, model.Viewer{ID: userId})
+}
+
+func (u *userServiceImpl) GetPrivacySettings(ctx context.Context, userId publicid.ID) (*model.PrivacySettings, error) {
+	user, err := u.repo.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.Privacy, nil
+}
+
+func (u *userServiceImpl) UpdatePrivacySettings(ctx context.Context, userId publicid.ID, update model.UpdatePrivacySettings) (*model.PrivacySettings, error) {
+	user, err := u.repo.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.ShowAge != nil {
+		user.Privacy.ShowAge = *update.ShowAge
+	}
+	if update.ShowBio != nil {
+		user.Privacy.ShowBio = *update.ShowBio
+	}
+	if update.ShowWebsite != nil {
+		user.Privacy.ShowWebsite = *update.ShowWebsite
+	}
+	if update.ShowPronouns != nil {
+		user.Privacy.ShowPronouns = *update.ShowPronouns
+	}
+	if update.ShowSocialMedias != nil {
+		user.Privacy.ShowSocialMedias = *update.ShowSocialMedias
+	}
+	user.UpdatedAt = time.Now()
+
+	err = u.repo.UpdatePrivacySettings(ctx, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.Privacy, nil
+}
+
+// UpdateAvatar store the image through the same sanitizing pipeline as the
+// photos, the previous avatar is removed from the storage.
+func (u *userServiceImpl) UpdateAvatar(ctx context.Context, userId publicid.ID, image []byte) (*model.UserProfile, error) {
+	cleanImage, err := imaging.Sanitize(image)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.repo.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	avatarUrl, err := u.storage.Save(ctx, uuid.NewString()+cleanImage.Extension, cleanImage.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.repo.UpdateAvatar(ctx, userId, avatarUrl)
+	if err != nil {
+		u.storage.Delete(ctx, avatarUrl)
+		return nil, err
+	}
+
+	u.deleteAvatarFile(ctx, user.AvatarUrl)
+
+	return u.GetUserProfile(ctx, userId, model.Viewer{ID: userId})
+}
+
+func (u *userServiceImpl) RemoveAvatar(ctx context.Context, userId publicid.ID) (*model.UserProfile, error) {
+	user, err := u.repo.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.repo.UpdateAvatar(ctx, userId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	u.deleteAvatarFile(ctx, user.AvatarUrl)
+
+	return u.GetUserProfile(ctx, userId, model.Viewer{ID: userId})
+}
+
+// deleteAvatarFile only log the failure, a leftover file does not affect the
+// user.
+func (u *userServiceImpl) deleteAvatarFile(ctx context.Context, avatarUrl string) {
+	if avatarUrl == "" {
+		return
+	}
+
+	err := u.storage.Delete(ctx, avatarUrl)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot delete avatar file", "error", err)
+	}
+}
+
+// UserRegister create the user, the username must not be reserved nor used by
+// another user whatever the case.
 func (u *userServiceImpl) UserRegister(ctx context.Context, userRegData model.UserSignUp) (*model.UserView, error) {
+	err := u.checkUsernameAvailable(ctx, 0, userRegData.Username)
+	if err != nil {
+		return nil, err
+	}
+
 	user := model.User{}
 	user.Username = userRegData.Username
 	user.Email = userRegData.Email
+	user.Role = model.UserRoleUser
+	user.Privacy = model.DefaultPrivacySettings
 	dobTime, err := helper.ParseStrToTime(userRegData.DOB)
 	if err != nil {
 		return nil, err
@@ -68,6 +301,7 @@ func (u *userServiceImpl) UserRegister(ctx context.Context, userRegData model.Us
 	userView.Email = user.Email
 	userView.Username = user.Username
 	userView.Age = helper.CountAge(*dobTime)
+	userView.Role = user.Role
 
 	return &userView, nil
 }
@@ -85,11 +319,19 @@ func (u *userServiceImpl) CheckIsAValidAge(dobStr string) (bool, error) {
 	return true, nil
 }
 
+// UserLogin restore the account of the user when it was deleted during the
+// grace period.
 func (u *userServiceImpl) UserLogin(ctx context.Context, userData model.UserSignIn) (*model.User, error) {
 	user, err := u.repo.GetUserByEmail(ctx, userData.Email)
 	if err != nil {
 		return nil, err
 	}
+	if user.ID == 0 {
+		user, err = u.repo.GetDeletedUserByEmail(ctx, userData.Email, time.Now().Add(-accountDeletionGracePeriod))
+		if err != nil {
+			return nil, err
+		}
+	}
 	if user.ID == 0 {
 		return nil, errors.New("invalid email or password")
 	}
@@ -99,6 +341,14 @@ func (u *userServiceImpl) UserLogin(ctx context.Context, userData model.UserSign
 		return nil, errors.New("invalid email or password")
 	}
 
+	if user.DeletedAt.Valid {
+		err = u.repo.RestoreUser(ctx, user.ID, user.DeletedAt.Time)
+		if err != nil {
+			return nil, err
+		}
+		user.DeletedAt = gorm.DeletedAt{}
+	}
+
 	return &user, nil
 }
 
@@ -110,23 +360,27 @@ func (u *userServiceImpl) GenerateAccessToken(ctx context.Context, user model.Us
 		Iss: "MyGram",
 		Aud: user.Username,
 		Sub: "access-token",
-		Exp: uint64(now.Add(12 * time.Hour).Unix()),
-		Iat: uint64(now.Unix()),
-		Nbf: uint64(now.Unix()),
+		Exp: uint32(now.Add(u.jwtConfig.TTL).Unix()),
+		Iat: uint32(now.Unix()),
+		Nbf: uint32(now.Unix()),
 	}
 
 	userClaim := model.AccessClaim{
 		StandardClaim: claim,
-		UserID:        uint32(user.ID),
+		UserID:        uint64(user.ID),
 		Username:      user.Username,
-		DOB:           user.DOB,
 	}
 
-	token, err = helper.GenerateToken(userClaim)
+	token, err = helper.GenerateToken(userClaim, u.jwtConfig.Secret)
 	return
 }
 
 func (u *userServiceImpl) EditUser(ctx context.Context, user model.User) (*model.UserView, error) {
+	err := u.checkUsernameAvailable(ctx, user.ID, user.Username)
+	if err != nil {
+		return nil, err
+	}
+
 	user.UpdatedAt = time.Now()
 	userFind, err := u.repo.GetUserByEmail(ctx, user.Email)
 	if err != nil {
@@ -150,12 +404,111 @@ func (u *userServiceImpl) EditUser(ctx context.Context, user model.User) (*model
 	return &userView, nil
 }
 
-func (u *userServiceImpl) DeleteUser(ctx context.Context, userId uint32) (err error) {
-	err = u.repo.DeleteUser(ctx, userId)
+// DeleteUser delete the account and the content of the user, the user can
+// restore them by logging in during the grace period, after that they are
+// purged by RunPurgeWorker.
+func (u *userServiceImpl) DeleteUser(ctx context.Context, userId publicid.ID) (err error) {
+	user, err := u.repo.GetUserById(ctx, userId)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.DeleteUser(ctx, userId, time.Now().Truncate(time.Microsecond))
 
 	if err != nil {
 		return
 	}
 
+	err = u.webhookSvc.Dispatch(ctx, model.WebhookEventUserDeleted, []publicid.ID{userId}, model.UserItem{ID: user.ID, Username: user.Username})
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot dispatch user webhook", "error", err)
+		err = nil
+	}
+
 	return
 }
+
+// RunPurgeWorker purge the accounts deleted for longer than the grace period
+// until stop is closed, a running purge is only aborted by ctx.
+func (u *userServiceImpl) RunPurgeWorker(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(accountPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			users, err := u.repo.GetUsersToPurge(ctx, time.Now().Add(-accountDeletionGracePeriod), accountPurgeBatchSize)
+			if err != nil {
+				slog.ErrorContext(ctx, "cannot get accounts to purge", "error", err)
+				continue
+			}
+
+			for _, user := range users {
+				u.purge(ctx, user.ID)
+			}
+		}
+	}
+}
+
+func (u *userServiceImpl) purge(ctx context.Context, userId publicid.ID) {
+	fileUrls, err := u.repo.PurgeUser(ctx, userId)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot purge account", "error", err)
+		return
+	}
+
+	for _, fileUrl := range fileUrls {
+		err = u.storage.Delete(ctx, fileUrl)
+		if err != nil && !errors.Is(err, infrastructure.ErrFileNotInStorage) {
+			slog.ErrorContext(ctx, "cannot delete file of purged account", "error", err)
+		}
+	}
+}
+
+// publicUserView hide the email and the age of the user from the viewers who
+// cannot see them.
+func publicUserView(user model.User, viewer model.Viewer) *model.UserPublicView {
+	userView := model.UserPublicView{}
+	userView.ID = user.ID
+	userView.Username = user.Username
+	userView.DisplayName = user.DisplayName
+	userView.AvatarUrl = user.AvatarUrl
+
+	if user.ID == 0 {
+		return &userView
+	}
+
+	canSeePrivate := viewer.CanSeePrivate(user.ID)
+	if canSeePrivate {
+		userView.Email = user.Email
+	}
+	if canSeePrivate || user.Privacy.ShowAge {
+		age := helper.CountAge(user.DOB)
+		userView.Age = &age
+	}
+
+	return &userView
+}
+
+// checkUsernameAvailable return ErrUsernameReserved or ErrUsernameTaken when
+// userId cannot use username.
+func (u *userServiceImpl) checkUsernameAvailable(ctx context.Context, userId publicid.ID, username string) error {
+	if model.IsReservedUsername(username) {
+		return ErrUsernameReserved
+	}
+
+	userFind, err := u.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if userFind.ID != 0 && userFind.ID != userId {
+		return ErrUsernameTaken
+	}
+
+	return nil
+}
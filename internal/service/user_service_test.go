@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zikri124/mygram-api/internal/infrastructure"
+	infraMocks "github.com/zikri124/mygram-api/internal/infrastructure/mock"
+	"github.com/zikri124/mygram-api/internal/model"
+	repoMocks "github.com/zikri124/mygram-api/internal/repository/mocks"
+	"github.com/zikri124/mygram-api/internal/service/mocks"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm"
+)
+
+func TestPublicUserView(t *testing.T) {
+	user := model.User{
+		ID:       7,
+		Username: "test",
+		Email:    "test@test.com",
+		DOB:      time.Now().AddDate(-20, 0, -1),
+		Role:     model.UserRoleUser,
+		Privacy:  model.DefaultPrivacySettings,
+	}
+
+	t.Run("anonymous viewer", func(t *testing.T) {
+		userView := publicUserView(user, model.Viewer{})
+		assert.Equal(t, "", userView.Email)
+		assert.Nil(t, userView.Age)
+	})
+
+	t.Run("the user and the admins see everything", func(t *testing.T) {
+		for _, viewer := range []model.Viewer{{ID: 7, Role: model.UserRoleUser}, {ID: 8, Role: model.UserRoleAdmin}} {
+			userView := publicUserView(user, viewer)
+			assert.Equal(t, "test@test.com", userView.Email)
+			assert.Equal(t, uint16(20), *userView.Age)
+		}
+	})
+
+	t.Run("public age", func(t *testing.T) {
+		publicAge := user
+		publicAge.Privacy.ShowAge = true
+
+		userView := publicUserView(publicAge, model.Viewer{ID: 8, Role: model.UserRoleUser})
+		assert.Equal(t, "", userView.Email)
+		assert.Equal(t, uint16(20), *userView.Age)
+	})
+}
+
+func TestAccountDeletion(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Now().Add(-24 * time.Hour).Truncate(time.Microsecond)
+	password, err := helper.GenerateHash("secret")
+	assert.Nil(t, err)
+
+	deletedUser := model.User{
+		ID:        1,
+		Username:  "alice",
+		Email:     "alice@test.com",
+		Password:  password,
+		DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true},
+	}
+
+	t.Run("delete the account and its content at the same time", func(t *testing.T) {
+		repo := repoMocks.NewUserRepository(t)
+		webhookSvc := mocks.NewWebhookService(t)
+
+		repo.On("GetUserById", ctx, publicid.ID(1)).Return(model.User{ID: 1, Username: "alice"}, nil)
+		repo.On("DeleteUser", ctx, publicid.ID(1), mock.MatchedBy(func(at time.Time) bool {
+			// restoring match the rows on deleted_at, it must survive the
+			// microsecond precision of the database
+			return at.Equal(at.Truncate(time.Microsecond))
+		})).Return(nil)
+		webhookSvc.On("Dispatch", ctx, model.WebhookEventUserDeleted, []publicid.ID{1}, model.UserItem{ID: 1, Username: "alice"}).Return(nil)
+
+		userService := userServiceImpl{repo: repo, webhookSvc: webhookSvc}
+		err := userService.DeleteUser(ctx, 1)
+		assert.Nil(t, err)
+	})
+
+	t.Run("login during the grace period restore the account", func(t *testing.T) {
+		repo := repoMocks.NewUserRepository(t)
+
+		repo.On("GetUserByEmail", ctx, "alice@test.com").Return(model.User{}, nil)
+		repo.On("GetDeletedUserByEmail", ctx, "alice@test.com", mock.MatchedBy(func(deletedSince time.Time) bool {
+			return time.Since(deletedSince) >= accountDeletionGracePeriod
+		})).Return(deletedUser, nil)
+		repo.On("RestoreUser", ctx, publicid.ID(1), deletedAt).Return(nil)
+
+		userService := userServiceImpl{repo: repo}
+		user, err := userService.UserLogin(ctx, model.UserSignIn{Email: "alice@test.com", Password: "secret"})
+		assert.Nil(t, err)
+		assert.Equal(t, publicid.ID(1), user.ID)
+		assert.False(t, user.DeletedAt.Valid)
+	})
+
+	t.Run("login with a wrong password does not restore the account", func(t *testing.T) {
+		repo := repoMocks.NewUserRepository(t)
+
+		repo.On("GetUserByEmail", ctx, "alice@test.com").Return(model.User{}, nil)
+		repo.On("GetDeletedUserByEmail", ctx, "alice@test.com", mock.Anything).Return(deletedUser, nil)
+
+		userService := userServiceImpl{repo: repo}
+		user, err := userService.UserLogin(ctx, model.UserSignIn{Email: "alice@test.com", Password: "wrong"})
+		assert.NotNil(t, err)
+		assert.Nil(t, user)
+	})
+
+	t.Run("purge delete the account and its files", func(t *testing.T) {
+		repo := repoMocks.NewUserRepository(t)
+		storage := infraMocks.NewFileStorage(t)
+
+		repo.On("PurgeUser", ctx, publicid.ID(1)).Return([]string{"/uploads/avatar.jpg", "https://cdn.example/1.jpg"}, nil)
+		storage.On("Delete", ctx, "/uploads/avatar.jpg").Return(nil)
+		storage.On("Delete", ctx, "https://cdn.example/1.jpg").Return(infrastructure.ErrFileNotInStorage)
+
+		userService := userServiceImpl{repo: repo, storage: storage}
+		userService.purge(ctx, 1)
+	})
+}
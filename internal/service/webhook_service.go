@@ -0,0 +1,328 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/internal/repository"
+	"github.com/zikri124/mygram-api/pkg/helper"
+	"github.com/zikri124/mygram-api/pkg/pagination"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"github.com/zikri124/mygram-api/pkg/safehttp"
+)
+
+const (
+	webhookMaxAttempts     = 8
+	webhookRetryBaseDelay  = 30 * time.Second
+	webhookRetryMaxDelay   = time.Hour
+	webhookRequestTimeout  = 10 * time.Second
+	webhookWorkerInterval  = 5 * time.Second
+	webhookClaimLease      = time.Minute
+	webhookClaimBatchSize  = 20
+	webhookMaxResponseBody = 1024
+)
+
+var ErrWebhookResponse = errors.New("webhook responded with a non 2xx status code")
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, userId publicid.ID, newWebhook model.CreateWebhook) (*model.WebhookCreateRes, error)
+	GetWebhooksByUserId(ctx context.Context, userId publicid.ID) ([]model.WebhookRes, error)
+	GetWebhookById(ctx context.Context, webhookId publicid.ID) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook model.Webhook) (*model.WebhookRes, error)
+	DeleteWebhook(ctx context.Context, webhookId publicid.ID) error
+	GetDeliveries(ctx context.Context, webhookId publicid.ID, params pagination.Params) (*pagination.Page, error)
+	GetDeliveryById(ctx context.Context, deliveryId publicid.ID) (*model.WebhookDelivery, error)
+	Redeliver(ctx context.Context, delivery model.WebhookDelivery) (*model.WebhookDelivery, error)
+	Dispatch(ctx context.Context, event string, userIds []publicid.ID, data interface{}) error
+	RunWorker(ctx context.Context, stop <-chan struct{})
+}
+
+type webhookServiceImpl struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+}
+
+func NewWebhookService(repo repository.WebhookRepository) WebhookService {
+	// the urls are given by the users, the client only reach public addresses
+	return &webhookServiceImpl{repo: repo, client: safehttp.NewClient(webhookRequestTimeout)}
+}
+
+func (w *webhookServiceImpl) CreateWebhook(ctx context.Context, userId publicid.ID, newWebhook model.CreateWebhook) (*model.WebhookCreateRes, error) {
+	secret, err := helper.GenerateSecret(32)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := model.Webhook{}
+	webhook.UserId = userId
+	webhook.Url = newWebhook.Url
+	webhook.Secret = secret
+	webhook.Events = newWebhook.Events
+	webhook.Active = true
+
+	err = w.repo.CreateWebhook(ctx, &webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookRes := model.WebhookCreateRes{}
+	webhookRes.WebhookRes = *ToWebhookRes(webhook)
+	webhookRes.Secret = webhook.Secret
+
+	return &webhookRes, nil
+}
+
+func (w *webhookServiceImpl) GetWebhooksByUserId(ctx context.Context, userId publicid.ID) ([]model.WebhookRes, error) {
+	webhooks, err := w.repo.GetWebhooksByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooksRes := []model.WebhookRes{}
+	for _, webhook := range webhooks {
+		webhooksRes = append(webhooksRes, *ToWebhookRes(webhook))
+	}
+
+	return webhooksRes, nil
+}
+
+func (w *webhookServiceImpl) GetWebhookById(ctx context.Context, webhookId publicid.ID) (*model.Webhook, error) {
+	return w.repo.GetWebhookById(ctx, webhookId)
+}
+
+func (w *webhookServiceImpl) UpdateWebhook(ctx context.Context, webhook model.Webhook) (*model.WebhookRes, error) {
+	webhook.UpdatedAt = time.Now()
+
+	err := w.repo.UpdateWebhook(ctx, &webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToWebhookRes(webhook), nil
+}
+
+func (w *webhookServiceImpl) DeleteWebhook(ctx context.Context, webhookId publicid.ID) error {
+	return w.repo.DeleteWebhook(ctx, webhookId)
+}
+
+func (w *webhookServiceImpl) GetDeliveries(ctx context.Context, webhookId publicid.ID, params pagination.Params) (*pagination.Page, error) {
+	deliveries, err := w.repo.GetDeliveriesByWebhookId(ctx, webhookId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.NewPage(deliveries, params, model.WebhookDelivery.SortKey)
+
+	return &page, nil
+}
+
+func (w *webhookServiceImpl) GetDeliveryById(ctx context.Context, deliveryId publicid.ID) (*model.WebhookDelivery, error) {
+	return w.repo.GetDeliveryById(ctx, deliveryId)
+}
+
+// Redeliver queue a new delivery with the same payload, the original delivery
+// is kept untouched in the log.
+func (w *webhookServiceImpl) Redeliver(ctx context.Context, delivery model.WebhookDelivery) (*model.WebhookDelivery, error) {
+	now := time.Now()
+
+	redelivery := model.WebhookDelivery{}
+	redelivery.WebhookId = delivery.WebhookId
+	redelivery.Event = delivery.Event
+	redelivery.Payload = delivery.Payload
+	redelivery.Status = model.WebhookDeliveryPending
+	redelivery.NextAttemptAt = &now
+
+	deliveries := []model.WebhookDelivery{redelivery}
+	err := w.repo.CreateDeliveries(ctx, deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deliveries[0], nil
+}
+
+// Dispatch queue a delivery of the event for every active webhook of userIds
+// subscribed to it, the deliveries are sent later by the worker.
+func (w *webhookServiceImpl) Dispatch(ctx context.Context, event string, userIds []publicid.ID, data interface{}) error {
+	webhooks, err := w.repo.GetActiveWebhooksByUserIds(ctx, userIds)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(model.WebhookPayload{Event: event, CreatedAt: now, Data: data})
+	if err != nil {
+		return err
+	}
+
+	deliveries := []model.WebhookDelivery{}
+	for _, webhook := range webhooks {
+		if !isSubscribed(webhook, event) {
+			continue
+		}
+
+		deliveries = append(deliveries, model.WebhookDelivery{
+			WebhookId:     webhook.ID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return w.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// RunWorker send the due deliveries until stop is closed, the deliveries
+// already claimed are sent with ctx. Several API instances can run the worker
+// at the same time.
+func (w *webhookServiceImpl) RunWorker(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(webhookWorkerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deliveries, err := w.repo.ClaimDueDeliveries(ctx, time.Now(), webhookClaimLease, webhookClaimBatchSize)
+			if err != nil {
+				slog.ErrorContext(ctx, "cannot claim webhook deliveries", "error", err)
+				continue
+			}
+
+			for _, delivery := range deliveries {
+				w.deliver(ctx, delivery)
+			}
+		}
+	}
+}
+
+func (w *webhookServiceImpl) deliver(ctx context.Context, delivery model.WebhookDelivery) {
+	webhook, err := w.repo.GetWebhookById(ctx, delivery.WebhookId)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot get webhook of delivery", "error", err)
+		return
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+
+	if webhook.ID == 0 {
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.Error = "webhook has been deleted"
+		delivery.NextAttemptAt = nil
+	} else {
+		statusCode, body, err := w.send(ctx, *webhook, delivery, now)
+		delivery.ResponseCode = statusCode
+		delivery.ResponseBody = body
+		delivery.Error = ""
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+
+		switch {
+		case err == nil:
+			delivery.Status = model.WebhookDeliverySuccess
+			delivery.DeliveredAt = &now
+			delivery.NextAttemptAt = nil
+		case delivery.Attempts >= webhookMaxAttempts:
+			delivery.Status = model.WebhookDeliveryFailed
+			delivery.NextAttemptAt = nil
+		default:
+			nextAttemptAt := now.Add(webhookRetryDelay(delivery.Attempts))
+			delivery.NextAttemptAt = &nextAttemptAt
+		}
+	}
+
+	err = w.repo.UpdateDelivery(ctx, &delivery)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot update webhook delivery", "error", err)
+	}
+}
+
+// send post the delivery payload, the request is signed with the webhook
+// secret in the X-Mygram-Signature header.
+func (w *webhookServiceImpl) send(ctx context.Context, webhook model.Webhook, delivery model.WebhookDelivery, now time.Time) (*int, string, error) {
+	payload := []byte(delivery.Payload)
+	timestamp := now.Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "MyGram-Webhook/1.0")
+	req.Header.Set("X-Mygram-Event", delivery.Event)
+	req.Header.Set("X-Mygram-Delivery", delivery.ID.String())
+	req.Header.Set("X-Mygram-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Mygram-Signature", "sha256="+helper.SignPayload(webhook.Secret, timestamp, payload))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, webhookMaxResponseBody))
+	statusCode := res.StatusCode
+
+	if statusCode < 200 || statusCode > 299 {
+		return &statusCode, string(body), ErrWebhookResponse
+	}
+
+	return &statusCode, string(body), nil
+}
+
+// ToWebhookRes return the webhook data safe to be sent to its owner, without
+// the secret.
+func ToWebhookRes(webhook model.Webhook) *model.WebhookRes {
+	webhookRes := model.WebhookRes{}
+	webhookRes.ID = webhook.ID
+	webhookRes.UserId = webhook.UserId
+	webhookRes.Url = webhook.Url
+	webhookRes.Events = webhook.Events
+	webhookRes.Active = webhook.Active
+	webhookRes.CreatedAt = webhook.CreatedAt
+	webhookRes.UpdatedAt = webhook.UpdatedAt
+
+	return &webhookRes
+}
+
+func isSubscribed(webhook model.Webhook, event string) bool {
+	for _, subscribedEvent := range webhook.Events {
+		if subscribedEvent == event {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookRetryDelay double the delay after every failed attempt, starting at
+// webhookRetryBaseDelay and capped at webhookRetryMaxDelay.
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookRetryMaxDelay {
+			return webhookRetryMaxDelay
+		}
+	}
+	return delay
+}
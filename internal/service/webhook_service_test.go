@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zikri124/mygram-api/internal/model"
+	"github.com/zikri124/mygram-api/pkg/safehttp"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhookRetryDelay(1))
+	assert.Equal(t, time.Minute, webhookRetryDelay(2))
+	assert.Equal(t, 4*time.Minute, webhookRetryDelay(4))
+	assert.Equal(t, time.Hour, webhookRetryDelay(10))
+}
+
+func TestWebhookSendRefusePrivateAddress(t *testing.T) {
+	hit := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer server.Close()
+
+	webhookService := NewWebhookService(nil).(*webhookServiceImpl)
+
+	_, body, err := webhookService.send(context.Background(), model.Webhook{Url: server.URL, Secret: "secret"}, model.WebhookDelivery{Payload: "{}"}, time.Now())
+	assert.ErrorIs(t, err, safehttp.ErrForbiddenAddress)
+	assert.Empty(t, body)
+	assert.False(t, hit)
+}
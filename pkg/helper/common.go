@@ -3,6 +3,8 @@ package helper
 import (
 	"math"
 	"time"
+
+	"github.com/zikri124/mygram-api/pkg/publicid"
 )
 
 func CountAge(dob time.Time) uint16 {
@@ -21,3 +23,9 @@ func ParseStrToTime(timeStr string) (*time.Time, error) {
 
 	return &time, nil
 }
+
+// ParseId decode the public id of a route param or query into its internal
+// id, it is the only place the ids coming from the requests are decoded.
+func ParseId(idStr string) (publicid.ID, error) {
+	return publicid.Parse(idStr)
+}
@@ -1,20 +1,24 @@
 package helper
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"log"
-	"os"
+	"fmt"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
+	"github.com/zikri124/mygram-api/pkg/publicid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 func GenerateHash(in string) (out string, err error) {
 	outByte, err := bcrypt.GenerateFromPassword([]byte(in), bcrypt.DefaultCost)
 	if err != nil {
-		log.Println("Error when generate hash password : ", err.Error())
+		err = fmt.Errorf("cannot hash password: %w", err)
 		return
 	}
 	return string(outByte), err
@@ -25,19 +29,36 @@ func CheckPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
-func GenerateToken(claim any) (token string, err error) {
-	jwtSecret := os.Getenv("JWT_SECRET")
+// GenerateSecret return a random hex encoded secret of size bytes.
+func GenerateSecret(size int) (string, error) {
+	secret := make([]byte, size)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
 
+// SignPayload return the hex HMAC-SHA256 of "timestamp.payload" with secret,
+// including the timestamp in the signature prevents replaying old payloads.
+func SignPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func GenerateToken(claim any, jwtSecret string) (token string, err error) {
 	jwtClaim := jwt.MapClaims{}
 	encodedClaim, err := json.Marshal(claim)
 	if err != nil {
-		log.Println("cannot mashal claim payload")
+		err = fmt.Errorf("cannot marshal claim payload: %w", err)
 		return
 	}
 
 	err = json.Unmarshal(encodedClaim, &jwtClaim)
 	if err != nil {
-		log.Println("cannot mapping claim to jwt claim")
+		err = fmt.Errorf("cannot map claim to jwt claim: %w", err)
 		return
 	}
 
@@ -46,15 +67,13 @@ func GenerateToken(claim any) (token string, err error) {
 	token, err = parseToken.SignedString([]byte(jwtSecret))
 
 	if err != nil {
-		log.Println("cannot generate token : ", err)
+		err = fmt.Errorf("cannot generate token: %w", err)
 		return
 	}
 	return
 }
 
-func ValidateToken(token string) (claim jwt.MapClaims, err error) {
-	jwtSecret := os.Getenv("JWT_SECRET")
-
+func ValidateToken(token string, jwtSecret string) (claim jwt.MapClaims, err error) {
 	jwtToken, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
@@ -64,29 +83,29 @@ func ValidateToken(token string) (claim jwt.MapClaims, err error) {
 	})
 
 	if err != nil {
-		log.Println("validating jwt error : ", err.Error())
+		return
 	}
 
 	claim, ok := jwtToken.Claims.(jwt.MapClaims)
 	if !ok {
-		log.Println("Error when translating claim")
+		err = errors.New("cannot translate jwt claim")
 		return
 	}
 
 	return
 }
 
-func GetUserIdFromGinCtx(ctx *gin.Context) (uint32, error) {
+func GetUserIdFromGinCtx(ctx *gin.Context) (publicid.ID, error) {
 	userIdRaw, isExist := ctx.Get("UserId")
 	if !isExist {
 		return 0, errors.New("cannot get payload in access token")
 	}
 
 	userIdFloat := userIdRaw.(float64)
-	userId := int(userIdFloat)
+	userId := publicid.ID(userIdFloat)
 	if userId == 0 {
 		return 0, errors.New("cannot get payload in access token")
 	}
 
-	return uint32(userId), nil
+	return userId, nil
 }
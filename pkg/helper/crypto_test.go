@@ -0,0 +1,17 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignPayload(t *testing.T) {
+	payload := []byte(`{"event":"photo.created"}`)
+
+	signature := SignPayload("secret", 1712000000, payload)
+	assert.Len(t, signature, 64)
+	assert.Equal(t, signature, SignPayload("secret", 1712000000, payload))
+	assert.NotEqual(t, signature, SignPayload("other", 1712000000, payload))
+	assert.NotEqual(t, signature, SignPayload("secret", 1712000001, payload))
+}
@@ -0,0 +1,116 @@
+package helper
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const maxHashtagLength = 100
+
+func isTagChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// ExtractHashtags return the lower cased and de-duplicated hashtags of a text
+// in the order they first appear, "#" inside a word (e.g. "abc#def") is not a
+// hashtag.
+func ExtractHashtags(text string) []string {
+	tags := []string{}
+	seen := map[string]bool{}
+
+	prev := ' '
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r != '#' || isTagChar(prev) || prev == '#' {
+			prev = r
+			i += size
+			continue
+		}
+
+		end := i + size
+		for end < len(text) {
+			next, nextSize := utf8.DecodeRuneInString(text[end:])
+			if !isTagChar(next) {
+				break
+			}
+			end += nextSize
+		}
+
+		tag := strings.ToLower(text[i+size : end])
+		if tag != "" && utf8.RuneCountInString(tag) <= maxHashtagLength && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+
+		prev = r
+		i = end
+	}
+
+	return tags
+}
+
+type MentionToken struct {
+	Username string
+	Offset   int
+	Length   int
+}
+
+func isUsernameChar(r rune) bool {
+	return r == '_' || r == '.' || (r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+// ExtractMentions return every "@username" of a text with its offset and
+// length counted in characters, the "@" included. "@" preceded by a word
+// character (e.g. an email address) is not a mention.
+func ExtractMentions(text string) []MentionToken {
+	mentions := []MentionToken{}
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && (isTagChar(runes[i-1]) || runes[i-1] == '@')) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && isUsernameChar(runes[end]) {
+			end++
+		}
+
+		// a dot at the end belong to the sentence, not to the username
+		for end > i+1 && runes[end-1] == '.' {
+			end--
+		}
+
+		if end > i+1 {
+			mentions = append(mentions, MentionToken{Username: string(runes[i+1 : end]), Offset: i, Length: end - i})
+		}
+
+		i = end - 1
+	}
+
+	return mentions
+}
+
+const maxSearchTerms = 8
+
+// PrefixTsQuery turn a free text search into a to_tsquery expression matching
+// every word as a prefix (e.g. "sun bal" become "sun:* & bal:*"). Words are
+// split like the postgres parser does, on everything but letters and digits,
+// so the result is safe to pass to to_tsquery. It is empty when the text has
+// no word.
+func PrefixTsQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) > maxSearchTerms {
+		words = words[:maxSearchTerms]
+	}
+
+	for i := range words {
+		words[i] += ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	t.Run("extract and normalize hashtags", func(t *testing.T) {
+		tags := ExtractHashtags("Sunset at #Bali with #friends, #bali again #Pantai_2024!")
+		assert.Equal(t, []string{"bali", "friends", "pantai_2024"}, tags)
+	})
+
+	t.Run("ignore hash inside a word or without tag", func(t *testing.T) {
+		tags := ExtractHashtags("issue abc#def, ## and # alone, C# is not #")
+		assert.Equal(t, []string{}, tags)
+	})
+
+	t.Run("unicode hashtags", func(t *testing.T) {
+		tags := ExtractHashtags("#Café #日本")
+		assert.Equal(t, []string{"café", "日本"}, tags)
+	})
+}
+
+func TestExtractMentions(t *testing.T) {
+	t.Run("extract mentions with offset", func(t *testing.T) {
+		mentions := ExtractMentions("Nice shot @john_doe! thanks to @Jane.Doe.")
+		assert.Equal(t, []MentionToken{
+			{Username: "john_doe", Offset: 10, Length: 9},
+			{Username: "Jane.Doe", Offset: 31, Length: 9},
+		}, mentions)
+	})
+
+	t.Run("offset counted in characters", func(t *testing.T) {
+		mentions := ExtractMentions("日本 @budi")
+		assert.Equal(t, []MentionToken{{Username: "budi", Offset: 3, Length: 5}}, mentions)
+	})
+
+	t.Run("ignore email address and lone @", func(t *testing.T) {
+		mentions := ExtractMentions("mail me at test@test.com @ @@")
+		assert.Equal(t, []MentionToken{}, mentions)
+	})
+}
+
+func TestPrefixTsQuery(t *testing.T) {
+	assert.Equal(t, "sun:* & bal:*", PrefixTsQuery("  Sun  bal"))
+	assert.Equal(t, "john:* & doe:*", PrefixTsQuery("@john_doe"))
+	assert.Equal(t, "sunset:*", PrefixTsQuery("#sunset"))
+	assert.Equal(t, "café:*", PrefixTsQuery("Café'"))
+	assert.Equal(t, "", PrefixTsQuery("'&|!:*()"))
+}
@@ -0,0 +1,205 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	tagMake         = 0x010F
+	tagModel        = 0x0110
+	tagOrientation  = 0x0112
+	tagExifIFD      = 0x8769
+	tagExposureTime = 0x829A
+	tagFNumber      = 0x829D
+	tagISO          = 0x8827
+	tagFocalLength  = 0x920A
+	tagLensModel    = 0xA434
+
+	typeAscii    = 2
+	typeShort    = 3
+	typeLong     = 4
+	typeRational = 5
+)
+
+var errNoExif = errors.New("image has no exif data")
+
+type exifData struct {
+	Orientation int
+	Camera      CameraInfo
+}
+
+// readJpegExif looks for the APP1 Exif segment of a jpeg file and reads the
+// orientation and the camera related tags from it.
+func readJpegExif(data []byte) (*exifData, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, errors.New("not a jpeg file")
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil, errors.New("invalid jpeg segment marker")
+		}
+
+		marker := data[pos+1]
+		// start of scan, the image data begin here so there is no more metadata
+		if marker == 0xDA || marker == 0xD9 {
+			break
+		}
+
+		segmentLen := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if segmentLen < 2 || pos+2+segmentLen > len(data) {
+			return nil, errors.New("invalid jpeg segment length")
+		}
+
+		segment := data[pos+4 : pos+2+segmentLen]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return parseTiff(segment[6:])
+		}
+
+		pos += 2 + segmentLen
+	}
+
+	return nil, errNoExif
+}
+
+func parseTiff(tiff []byte) (*exifData, error) {
+	if len(tiff) < 8 {
+		return nil, errors.New("exif header is too short")
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("invalid exif byte order")
+	}
+
+	if order.Uint16(tiff[2:4]) != 42 {
+		return nil, errors.New("invalid exif header")
+	}
+
+	exif := exifData{Orientation: 1}
+	r := tiffReader{data: tiff, order: order}
+
+	exifIFDOffset, err := r.readIFD(order.Uint32(tiff[4:8]), &exif)
+	if err != nil {
+		return nil, err
+	}
+
+	if exifIFDOffset != 0 {
+		if _, err := r.readIFD(exifIFDOffset, &exif); err != nil {
+			return nil, err
+		}
+	}
+
+	return &exif, nil
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// readIFD reads the entries of an image file directory into exif and return
+// the offset of the Exif sub directory if the directory points to one.
+func (r *tiffReader) readIFD(offset uint32, exif *exifData) (uint32, error) {
+	if int(offset)+2 > len(r.data) {
+		return 0, errors.New("exif directory out of range")
+	}
+
+	count := int(r.order.Uint16(r.data[offset : offset+2]))
+	start := int(offset) + 2
+	if start+count*12 > len(r.data) {
+		return 0, errors.New("exif directory entries out of range")
+	}
+
+	var exifIFDOffset uint32
+	for i := 0; i < count; i++ {
+		entry := r.data[start+i*12 : start+(i+1)*12]
+		tag := r.order.Uint16(entry[0:2])
+		dataType := r.order.Uint16(entry[2:4])
+		valueCount := r.order.Uint32(entry[4:8])
+
+		switch tag {
+		case tagOrientation:
+			if dataType == typeShort {
+				exif.Orientation = int(r.order.Uint16(entry[8:10]))
+			}
+		case tagExifIFD:
+			if dataType == typeLong {
+				exifIFDOffset = r.order.Uint32(entry[8:12])
+			}
+		case tagMake:
+			exif.Camera.Make = r.ascii(dataType, valueCount, entry[8:12])
+		case tagModel:
+			exif.Camera.Model = r.ascii(dataType, valueCount, entry[8:12])
+		case tagLensModel:
+			exif.Camera.LensModel = r.ascii(dataType, valueCount, entry[8:12])
+		case tagExposureTime:
+			if num, den, ok := r.rational(dataType, entry[8:12]); ok && den != 0 {
+				if num == 1 || num == 0 {
+					exif.Camera.ExposureTime = fmt.Sprintf("%d/%d", num, den)
+				} else {
+					exif.Camera.ExposureTime = fmt.Sprintf("%g", float64(num)/float64(den))
+				}
+			}
+		case tagFNumber:
+			if num, den, ok := r.rational(dataType, entry[8:12]); ok && den != 0 {
+				exif.Camera.FNumber = math.Round(float64(num)/float64(den)*10) / 10
+			}
+		case tagFocalLength:
+			if num, den, ok := r.rational(dataType, entry[8:12]); ok && den != 0 {
+				exif.Camera.FocalLength = math.Round(float64(num)/float64(den)*10) / 10
+			}
+		case tagISO:
+			if dataType == typeShort {
+				exif.Camera.ISO = uint32(r.order.Uint16(entry[8:10]))
+			} else if dataType == typeLong {
+				exif.Camera.ISO = r.order.Uint32(entry[8:12])
+			}
+		}
+	}
+
+	return exifIFDOffset, nil
+}
+
+func (r *tiffReader) ascii(dataType uint16, count uint32, value []byte) string {
+	if dataType != typeAscii || count == 0 {
+		return ""
+	}
+
+	raw := value
+	if count > 4 {
+		offset := r.order.Uint32(value)
+		if uint64(offset)+uint64(count) > uint64(len(r.data)) {
+			return ""
+		}
+		raw = r.data[offset : offset+count]
+	} else {
+		raw = raw[:count]
+	}
+
+	return strings.TrimSpace(strings.TrimRight(string(raw), "\x00"))
+}
+
+func (r *tiffReader) rational(dataType uint16, value []byte) (uint32, uint32, bool) {
+	if dataType != typeRational {
+		return 0, 0, false
+	}
+
+	offset := r.order.Uint32(value)
+	if uint64(offset)+8 > uint64(len(r.data)) {
+		return 0, 0, false
+	}
+
+	return r.order.Uint32(r.data[offset : offset+4]), r.order.Uint32(r.data[offset+4 : offset+8]), true
+}
@@ -0,0 +1,139 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+)
+
+type CameraInfo struct {
+	Make         string
+	Model        string
+	LensModel    string
+	ExposureTime string
+	FNumber      float64
+	FocalLength  float64
+	ISO          uint32
+}
+
+type Result struct {
+	Data        []byte
+	Format      string
+	Extension   string
+	ContentType string
+	Width       int
+	Height      int
+	Camera      CameraInfo
+}
+
+// MaxPixels is the largest width x height accepted, a small file can
+// declare a huge image which would take gigabytes once decoded.
+const MaxPixels = 50_000_000
+
+var ErrUnsupportedFormat = errors.New("unsupported image format, only jpeg and png are allowed")
+
+var ErrImageTooLarge = errors.New("image dimensions are too large, maximum is 50 megapixels")
+
+// Sanitize decode the uploaded image and encode it again so every metadata
+// (EXIF, GPS, XMP, text chunks, ...) is dropped from the stored file. The EXIF
+// orientation is applied to the pixels before the metadata is removed so the
+// image is still displayed the right way up.
+func Sanitize(data []byte) (*Result, error) {
+	// only the header is read, the dimensions are checked before the pixels
+	// are allocated
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	result := Result{Format: format}
+
+	if format == "jpeg" {
+		// a broken exif segment should not reject the upload, the metadata is
+		// removed anyway
+		exif, err := readJpegExif(data)
+		if err == nil {
+			img = applyOrientation(img, exif.Orientation)
+			result.Camera = exif.Camera
+		}
+	}
+
+	buf := bytes.Buffer{}
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+		result.Extension = ".jpg"
+		result.ContentType = "image/jpeg"
+	case "png":
+		err = png.Encode(&buf, img)
+		result.Extension = ".png"
+		result.ContentType = "image/png"
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.Data = buf.Bytes()
+	result.Width = img.Bounds().Dx()
+	result.Height = img.Bounds().Dy()
+
+	return &result, nil
+}
+
+// applyOrientation transform the image according to the EXIF orientation tag
+// (1 to 8), 1 or unknown values return the image untouched.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		for x := 0; x < dstW; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+
+			dst.Set(x, y, color.NRGBAModel.Convert(img.At(bounds.Min.X+sx, bounds.Min.Y+sy)))
+		}
+	}
+
+	return dst
+}
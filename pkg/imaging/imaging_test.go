@@ -0,0 +1,133 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newExifSegment build an APP1 segment holding a little endian TIFF with
+// Make, Orientation, a GPS pointer and an Exif IFD containing the ISO.
+func newExifSegment(orientation uint16) []byte {
+	le := binary.LittleEndian
+	tiff := make([]byte, 0, 128)
+	tiff = append(tiff, 'I', 'I', 42, 0, 8, 0, 0, 0)
+
+	entry := func(tag, dataType uint16, count, value uint32) {
+		tiff = le.AppendUint16(tiff, tag)
+		tiff = le.AppendUint16(tiff, dataType)
+		tiff = le.AppendUint32(tiff, count)
+		tiff = le.AppendUint32(tiff, value)
+	}
+
+	// IFD0 : 8 -> 62, Exif IFD : 62 -> 80, "Canon\0" : 80 -> 86, GPS data : 86
+	tiff = le.AppendUint16(tiff, 4)
+	entry(tagMake, typeAscii, 6, 80)
+	entry(tagOrientation, typeShort, 1, uint32(orientation))
+	entry(tagExifIFD, typeLong, 1, 62)
+	entry(0x8825, typeLong, 1, 86)
+	tiff = le.AppendUint32(tiff, 0)
+
+	tiff = le.AppendUint16(tiff, 1)
+	entry(tagISO, typeShort, 1, 200)
+	tiff = le.AppendUint32(tiff, 0)
+
+	tiff = append(tiff, []byte("Canon\x00")...)
+	tiff = append(tiff, []byte("GPS-51.5007N-0.1246W")...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+
+	return append(segment, payload...)
+}
+
+func newJpegWithExif(t *testing.T, w, h int, orientation uint16) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	buf := bytes.Buffer{}
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("an error was not expected when encode jpeg : '%s'", err)
+	}
+
+	raw := buf.Bytes()
+	out := append([]byte{}, raw[:2]...)
+	out = append(out, newExifSegment(orientation)...)
+
+	return append(out, raw[2:]...)
+}
+
+func TestSanitize(t *testing.T) {
+	t.Run("strip metadata and apply orientation", func(t *testing.T) {
+		data := newJpegWithExif(t, 3, 2, 6)
+
+		res, err := Sanitize(data)
+		assert.Nil(t, err)
+		assert.Equal(t, "jpeg", res.Format)
+		assert.Equal(t, 2, res.Width)
+		assert.Equal(t, 3, res.Height)
+		assert.Equal(t, "Canon", res.Camera.Make)
+		assert.Equal(t, uint32(200), res.Camera.ISO)
+		assert.False(t, bytes.Contains(res.Data, []byte("Exif")))
+		assert.False(t, bytes.Contains(res.Data, []byte("GPS-51.5007N")))
+	})
+
+	t.Run("reject not an image file", func(t *testing.T) {
+		res, err := Sanitize([]byte("not an image"))
+		assert.Equal(t, ErrUnsupportedFormat, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestApplyOrientation(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, red)
+
+	t.Run("rotate 90 clockwise", func(t *testing.T) {
+		res := applyOrientation(img, 6)
+		assert.Equal(t, image.Rect(0, 0, 2, 3), res.Bounds())
+		assert.Equal(t, red, res.At(1, 0))
+	})
+
+	t.Run("rotate 90 counter clockwise", func(t *testing.T) {
+		res := applyOrientation(img, 8)
+		assert.Equal(t, red, res.At(0, 2))
+	})
+
+	t.Run("normal orientation untouched", func(t *testing.T) {
+		assert.Equal(t, img, applyOrientation(img, 1))
+	})
+}
+
+// newPngHeader return the signature and IHDR chunk of a png declaring the
+// dimensions, without any pixel data.
+func newPngHeader(w, h uint32) []byte {
+	ihdr := []byte("IHDR")
+	ihdr = binary.BigEndian.AppendUint32(ihdr, w)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, h)
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+
+	out := []byte("\x89PNG\r\n\x1a\n")
+	out = binary.BigEndian.AppendUint32(out, uint32(len(ihdr)-4))
+	out = append(out, ihdr...)
+
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestSanitizeTooLarge(t *testing.T) {
+	_, err := Sanitize(newPngHeader(100_000, 100_000))
+	assert.Equal(t, ErrImageTooLarge, err)
+
+	_, err = Sanitize(newPngHeader(1<<31-1, 2))
+	assert.Equal(t, ErrImageTooLarge, err)
+
+	// the header is accepted, the missing pixels fail the decoding
+	_, err = Sanitize(newPngHeader(100, 100))
+	assert.Equal(t, ErrUnsupportedFormat, err)
+}
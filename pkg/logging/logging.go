@@ -0,0 +1,48 @@
+// Package logging set up the JSON structured logs, every line logged with the
+// context of a request carry its request id.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/zikri124/mygram-api/pkg/requestid"
+)
+
+type Config struct {
+	// Level is debug, info, warn or error, the SQL queries are logged at
+	// debug.
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+func (config Config) ParseLevel() (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(config.Level))
+	return level, err
+}
+
+// New return a JSON logger writing to w the records of level and above.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(&requestIdHandler{Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})})
+}
+
+type requestIdHandler struct {
+	slog.Handler
+}
+
+func (h *requestIdHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *requestIdHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &requestIdHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *requestIdHandler) WithGroup(name string) slog.Handler {
+	return &requestIdHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zikri124/mygram-api/pkg/requestid"
+)
+
+func TestNew(t *testing.T) {
+	out := bytes.Buffer{}
+	logger := New(&out, slog.LevelInfo).With("component", "test")
+
+	logger.DebugContext(context.Background(), "hidden")
+	logger.InfoContext(requestid.NewContext(context.Background(), "abc"), "served", "status", 200)
+
+	line := map[string]any{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(t, "served", line["msg"])
+	assert.Equal(t, "abc", line["request_id"])
+	assert.Equal(t, "test", line["component"])
+	assert.Equal(t, float64(200), line["status"])
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := Config{Level: "debug"}.ParseLevel()
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, level)
+
+	_, err = Config{Level: "verbose"}.ParseLevel()
+	assert.Error(t, err)
+}
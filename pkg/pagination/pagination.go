@@ -0,0 +1,188 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zikri124/mygram-api/pkg/publicid"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+const (
+	SortNewest        = "newest"
+	SortOldest        = "oldest"
+	SortMostLiked     = "most_liked"
+	SortMostCommented = "most_commented"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidLimit  = fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	ErrInvalidSort   = errors.New("invalid sort")
+)
+
+// Cursor is the position after the last item of a page, Value is the sort
+// key of that item (unix nano for time keys) and Id breaks the ties.
+type Cursor struct {
+	Sort  string      `json:"s"`
+	Value int64       `json:"v"`
+	Id    publicid.ID `json:"i"`
+}
+
+type Params struct {
+	Limit  int
+	Sort   string
+	Cursor *Cursor
+}
+
+// Page is the envelope of every list response, Data is the slice of items.
+type Page struct {
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor"`
+	Limit      int         `json:"limit"`
+}
+
+// SortKey describe how a sort option is applied on a query, Column is the
+// SQL expression of the sort value.
+type SortKey struct {
+	Column string
+	Desc   bool
+	Time   bool
+}
+
+type SortKeys map[string]SortKey
+
+// KeyFunc return the sort value and the id of an item for the given sort.
+type KeyFunc[T any] func(item T, sort string) (int64, publicid.ID)
+
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(cursor string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	decoded := Cursor{}
+	err = json.Unmarshal(raw, &decoded)
+	if err != nil || decoded.Sort == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &decoded, nil
+}
+
+// Parse validate the raw limit, sort and cursor of a request, an empty sort
+// fallback to defaultSort and a cursor is only valid for the sort it was
+// created with.
+func Parse(limit string, sort string, cursor string, defaultSort string, sorts ...string) (Params, error) {
+	params := Params{Limit: DefaultLimit, Sort: defaultSort}
+
+	if limit != "" {
+		var err error
+		params.Limit, err = strconv.Atoi(limit)
+		if err != nil || params.Limit < 1 || params.Limit > MaxLimit {
+			return Params{}, ErrInvalidLimit
+		}
+	}
+
+	if sort != "" {
+		params.Sort = sort
+	}
+	if !isAllowedSort(params.Sort, sorts) {
+		return Params{}, fmt.Errorf("%w, must be one of %s", ErrInvalidSort, strings.Join(sorts, ", "))
+	}
+
+	if cursor != "" {
+		decoded, err := DecodeCursor(cursor)
+		if err != nil {
+			return Params{}, err
+		}
+		if decoded.Sort != params.Sort {
+			return Params{}, ErrInvalidCursor
+		}
+		params.Cursor = decoded
+	}
+
+	return params, nil
+}
+
+// FromQuery is Parse reading the limit, sort and cursor query parameters.
+func FromQuery(query url.Values, defaultSort string, sorts ...string) (Params, error) {
+	return Parse(query.Get("limit"), query.Get("sort"), query.Get("cursor"), defaultSort, sorts...)
+}
+
+// Apply order the query by the sort key of params, skip the rows up to the
+// cursor and fetch one more row than the limit to know if there is a next
+// page.
+func Apply(query *gorm.DB, params Params, keys SortKeys, idColumn string) *gorm.DB {
+	key := keys[params.Sort]
+
+	direction, operator := "ASC", ">"
+	if key.Desc {
+		direction, operator = "DESC", "<"
+	}
+
+	if params.Cursor != nil {
+		var value interface{} = params.Cursor.Value
+		if key.Time {
+			value = time.Unix(0, params.Cursor.Value)
+		}
+		query = query.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", key.Column, idColumn, operator), value, params.Cursor.Id)
+	}
+
+	return query.
+		Order(fmt.Sprintf("%s %s, %s %s", key.Column, direction, idColumn, direction)).
+		Limit(params.Limit + 1)
+}
+
+// Trim drop the extra row fetched with Apply and return the cursor pointing
+// after the last item kept, the cursor is empty on the last page.
+func Trim[T any](items []T, params Params, keyOf KeyFunc[T]) ([]T, string) {
+	if items == nil {
+		items = []T{}
+	}
+
+	if len(items) <= params.Limit {
+		return items, ""
+	}
+
+	items = items[:params.Limit]
+	value, id := keyOf(items[len(items)-1], params.Sort)
+
+	return items, Cursor{Sort: params.Sort, Value: value, Id: id}.Encode()
+}
+
+// NewPage build the page from the rows fetched with Apply.
+func NewPage[T any](items []T, params Params, keyOf KeyFunc[T]) Page {
+	data, nextCursor := Trim(items, params, keyOf)
+	return Page{Data: data, NextCursor: nextCursor, Limit: params.Limit}
+}
+
+// TimeValue is the cursor value of a time sort key.
+func TimeValue(t time.Time) int64 {
+	return t.UnixNano()
+}
+
+func isAllowedSort(sort string, sorts []string) bool {
+	for _, allowed := range sorts {
+		if sort == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zikri124/mygram-api/pkg/publicid"
+)
+
+func TestParse(t *testing.T) {
+	sorts := []string{SortNewest, SortOldest}
+
+	t.Run("defaults", func(t *testing.T) {
+		params, err := Parse("", "", "", SortNewest, sorts...)
+		assert.Nil(t, err)
+		assert.Equal(t, Params{Limit: DefaultLimit, Sort: SortNewest}, params)
+	})
+
+	t.Run("invalid limit and sort", func(t *testing.T) {
+		_, err := Parse("0", "", "", SortNewest, sorts...)
+		assert.Equal(t, ErrInvalidLimit, err)
+
+		_, err = Parse("101", "", "", SortNewest, sorts...)
+		assert.Equal(t, ErrInvalidLimit, err)
+
+		_, err = Parse("", SortMostLiked, "", SortNewest, sorts...)
+		assert.True(t, errors.Is(err, ErrInvalidSort))
+	})
+
+	t.Run("cursor round trip", func(t *testing.T) {
+		cursor := Cursor{Sort: SortOldest, Value: 1712000000123456000, Id: 42}
+
+		params, err := Parse("10", SortOldest, cursor.Encode(), SortNewest, sorts...)
+		assert.Nil(t, err)
+		assert.Equal(t, &cursor, params.Cursor)
+
+		_, err = Parse("10", SortNewest, cursor.Encode(), SortNewest, sorts...)
+		assert.Equal(t, ErrInvalidCursor, err)
+
+		_, err = Parse("10", "", "not-a-cursor", SortNewest, sorts...)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+}
+
+func TestTrim(t *testing.T) {
+	keyOf := func(item int, sort string) (int64, publicid.ID) {
+		return int64(item * 10), publicid.ID(item)
+	}
+	params := Params{Limit: 2, Sort: SortNewest}
+
+	items, nextCursor := Trim([]int{1, 2, 3}, params, keyOf)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, Cursor{Sort: SortNewest, Value: 20, Id: 2}.Encode(), nextCursor)
+
+	items, nextCursor = Trim([]int{1, 2}, params, keyOf)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, "", nextCursor)
+
+	items, _ = Trim[int](nil, params, keyOf)
+	assert.Equal(t, []int{}, items)
+}
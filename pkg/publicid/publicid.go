@@ -0,0 +1,168 @@
+// Package publicid hide the internal ids behind opaque public ids.
+//
+// An ID is stored as its internal key but written in JSON, and parsed from
+// routes and requests, as 11 base62 characters. The key is shuffled by a
+// keyed Feistel permutation before the encoding so following ids do not give
+// following public ids and ids cannot be guessed without the secret.
+package publicid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	length   = 11
+	rounds   = 4
+)
+
+var ErrInvalidId = errors.New("invalid id")
+
+var secret []byte
+
+// SetSecret set the key of the permutation, changing it change every public
+// id so it must stay the same for the life of the links given to clients.
+func SetSecret(key string) {
+	secret = []byte(key)
+}
+
+type ID uint64
+
+// Parse decode a public id, the zero id has no public form.
+func Parse(publicId string) (ID, error) {
+	if len(publicId) != length {
+		return 0, ErrInvalidId
+	}
+
+	var value uint64
+	for i := 0; i < length; i++ {
+		digit := strings.IndexByte(alphabet, publicId[i])
+		if digit < 0 {
+			return 0, ErrInvalidId
+		}
+
+		// 11 base62 digits go up to about 2^65, refuse what overflow uint64
+		if value > (math.MaxUint64-uint64(digit))/62 {
+			return 0, ErrInvalidId
+		}
+		value = value*62 + uint64(digit)
+	}
+
+	id := ID(unpermute(value))
+	if id == 0 {
+		return 0, ErrInvalidId
+	}
+
+	return id, nil
+}
+
+// String return the public id, empty for the zero id.
+func (id ID) String() string {
+	if id == 0 {
+		return ""
+	}
+
+	value := permute(uint64(id))
+	encoded := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		encoded[i] = alphabet[value%62]
+		value /= 62
+	}
+
+	return string(encoded)
+}
+
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText accept an empty text as the zero id so optional ids can be
+// left empty.
+func (id *ID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = 0
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
+
+func (id ID) MarshalJSON() ([]byte, error) {
+	if id == 0 {
+		return []byte("null"), nil
+	}
+	return json.Marshal(id.String())
+}
+
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*id = 0
+		return nil
+	}
+
+	var publicId string
+	err := json.Unmarshal(data, &publicId)
+	if err != nil {
+		return ErrInvalidId
+	}
+
+	return id.UnmarshalText([]byte(publicId))
+}
+
+func (id ID) Value() (driver.Value, error) {
+	return int64(id), nil
+}
+
+func (id *ID) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*id = 0
+	case int64:
+		*id = ID(value)
+	case int32:
+		*id = ID(value)
+	default:
+		return fmt.Errorf("cannot scan %T into publicid.ID", src)
+	}
+	return nil
+}
+
+func permute(value uint64) uint64 {
+	left, right := uint32(value>>32), uint32(value)
+	for round := 0; round < rounds; round++ {
+		left, right = right, left^roundKey(round, right)
+	}
+	return uint64(left)<<32 | uint64(right)
+}
+
+func unpermute(value uint64) uint64 {
+	left, right := uint32(value>>32), uint32(value)
+	for round := rounds - 1; round >= 0; round-- {
+		left, right = right^roundKey(round, left), left
+	}
+	return uint64(left)<<32 | uint64(right)
+}
+
+func roundKey(round int, half uint32) uint32 {
+	input := make([]byte, 5)
+	input[0] = byte(round)
+	binary.BigEndian.PutUint32(input[1:], half)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(input)
+	return binary.BigEndian.Uint32(mac.Sum(nil))
+}
@@ -0,0 +1,93 @@
+package publicid
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zikri124/mygram-api/pkg/snowflake"
+)
+
+func TestParse(t *testing.T) {
+	SetSecret("test secret")
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, id := range []ID{1, 2, 42, 1 << 32, 1<<53 - 1, 1<<64 - 1} {
+			publicId := id.String()
+			assert.Len(t, publicId, length)
+
+			parsed, err := Parse(publicId)
+			assert.Nil(t, err)
+			assert.Equal(t, id, parsed)
+		}
+	})
+
+	t.Run("ids from before the snowflake ids", func(t *testing.T) {
+		// the 32-bit ids of the old rows are stored and encoded like the
+		// snowflake ids, which are always above them
+		legacyId := ID(4_000_000_000)
+		snowflakeId := ID(snowflake.NextID())
+		assert.Greater(t, snowflakeId, legacyId)
+
+		for _, id := range []ID{legacyId, snowflakeId} {
+			stored, err := id.Value()
+			assert.Nil(t, err)
+
+			scanned := ID(0)
+			assert.Nil(t, scanned.Scan(stored))
+			assert.Equal(t, id, scanned)
+
+			parsed, err := Parse(id.String())
+			assert.Nil(t, err)
+			assert.Equal(t, id, parsed)
+		}
+
+		scanned := ID(0)
+		assert.Nil(t, scanned.Scan(int32(42)))
+		assert.Equal(t, ID(42), scanned)
+	})
+
+	t.Run("public ids above the largest id", func(t *testing.T) {
+		// LygHa16AHYF is 2^64-1, the next one overflow on the last digit
+		// only and the last one on the multiplication
+		value, err := Parse("LygHa16AHYF")
+		assert.Nil(t, err)
+		assert.Equal(t, ID(unpermute(math.MaxUint64)), value)
+
+		for _, publicId := range []string{"LygHa16AHYG", "LygHa16AHYz", "LygHa16AHZ0", "zzzzzzzzzzz"} {
+			_, err := Parse(publicId)
+			assert.Equal(t, ErrInvalidId, err, publicId)
+		}
+	})
+
+	t.Run("following ids do not look alike", func(t *testing.T) {
+		assert.NotEqual(t, ID(1).String()[:length-1], ID(2).String()[:length-1])
+	})
+
+	t.Run("invalid public ids", func(t *testing.T) {
+		for _, publicId := range []string{"", "1", "42", "abc-defghij", "zzzzzzzzzzz", ID(7).String() + "0"} {
+			_, err := Parse(publicId)
+			assert.Equal(t, ErrInvalidId, err, publicId)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		payload := struct {
+			ID       ID  `json:"id"`
+			ParentId *ID `json:"parent_id"`
+		}{ID: 42}
+
+		encoded, err := json.Marshal(payload)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"id":"`+ID(42).String()+`","parent_id":null}`, string(encoded))
+
+		err = json.Unmarshal([]byte(`{"id":"`+ID(7).String()+`","parent_id":"`+ID(8).String()+`"}`), &payload)
+		assert.Nil(t, err)
+		assert.Equal(t, ID(7), payload.ID)
+		assert.Equal(t, ID(8), *payload.ParentId)
+
+		err = json.Unmarshal([]byte(`{"id":7}`), &payload)
+		assert.Equal(t, ErrInvalidId, err)
+	})
+}
@@ -0,0 +1,48 @@
+// Package requestid carry the id of a request in its context so the logs and
+// the error responses of the request can be matched.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Header is the request header a client or a proxy can set to choose the id,
+// the id is also sent back in this response header.
+const Header = "X-Request-ID"
+
+const maxLength = 128
+
+type contextKey struct{}
+
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid report whether a client id can be used as is, the id is written in
+// the logs so only short ids of safe characters are accepted.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for _, char := range id {
+		isAlphaNumeric := char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9'
+		if !isAlphaNumeric && char != '-' && char != '_' && char != '.' && char != ':' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext return the id of ctx, empty outside of a request.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -1,6 +1,19 @@
 package response
 
+import (
+	"context"
+
+	"github.com/zikri124/mygram-api/pkg/requestid"
+)
+
 type ErrorResponse struct {
 	Message string   `json:"message"`
 	Errors  []string `json:"errors,omitempty"`
+	// RequestId match the error with the logs of the request.
+	RequestId string `json:"request_id,omitempty"`
+}
+
+// NewErrorResponse return the error of the request of ctx.
+func NewErrorResponse(ctx context.Context, message string, errors ...string) ErrorResponse {
+	return ErrorResponse{Message: message, Errors: errors, RequestId: requestid.FromContext(ctx)}
 }
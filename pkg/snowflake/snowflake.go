@@ -0,0 +1,82 @@
+// Package snowflake generate time ordered 64-bit ids.
+//
+// An id is the milliseconds since Epoch (41 bits), the node of the instance
+// (4 bits) and a sequence within the millisecond (8 bits). Ids stay below
+// 2^53 so clients reading JSON numbers as float64 (JavaScript, the JWT
+// claims) get them exactly, and above 2^32 so they never collide with the
+// 32-bit ids given before.
+package snowflake
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	nodeBits     = 4
+	sequenceBits = 8
+
+	MaxNode     = 1<<nodeBits - 1
+	maxSequence = 1<<sequenceBits - 1
+)
+
+// Epoch is 2024-01-01 UTC.
+var Epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var ErrInvalidNode = errors.New("snowflake node must be between 0 and 15")
+
+type Generator struct {
+	mu       sync.Mutex
+	node     uint64
+	last     int64
+	sequence uint64
+	now      func() time.Time
+}
+
+func NewGenerator(node int) (*Generator, error) {
+	if node < 0 || node > MaxNode {
+		return nil, ErrInvalidNode
+	}
+
+	return &Generator{node: uint64(node), now: time.Now}, nil
+}
+
+// Next return a new id, greater than every id given before by the generator.
+// When the clock goes back or a millisecond runs out of sequence the
+// generator keep counting from its last millisecond instead of waiting.
+func (g *Generator) Next() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	millis := g.now().Sub(Epoch).Milliseconds()
+	if millis > g.last {
+		g.last = millis
+		g.sequence = 0
+	} else if g.sequence < maxSequence {
+		g.sequence++
+	} else {
+		g.last++
+		g.sequence = 0
+	}
+
+	return uint64(g.last)<<(nodeBits+sequenceBits) | g.node<<sequenceBits | g.sequence
+}
+
+var defaultGenerator, _ = NewGenerator(0)
+
+// SetNode set the node of the ids given by NextID, every instance sharing a
+// database must have its own node.
+func SetNode(node int) error {
+	generator, err := NewGenerator(node)
+	if err != nil {
+		return err
+	}
+
+	defaultGenerator = generator
+	return nil
+}
+
+func NextID() uint64 {
+	return defaultGenerator.Next()
+}
@@ -0,0 +1,40 @@
+package snowflake
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNext(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	generator, err := NewGenerator(3)
+	assert.Nil(t, err)
+	generator.now = func() time.Time { return now }
+
+	t.Run("ids grow and keep their node", func(t *testing.T) {
+		previous := uint64(0)
+		for i := 0; i < 3*(maxSequence+1); i++ {
+			id := generator.Next()
+			assert.Greater(t, id, previous)
+			assert.Equal(t, uint64(3), id>>sequenceBits&MaxNode)
+			previous = id
+		}
+	})
+
+	t.Run("ids grow when the clock goes back", func(t *testing.T) {
+		previous := generator.Next()
+		now = now.Add(-time.Second)
+		assert.Greater(t, generator.Next(), previous)
+	})
+
+	t.Run("ids are between 2^32 and 2^53", func(t *testing.T) {
+		id := generator.Next()
+		assert.Greater(t, id, uint64(1<<32))
+		assert.Less(t, id, uint64(1<<53))
+	})
+
+	_, err = NewGenerator(MaxNode + 1)
+	assert.Equal(t, ErrInvalidNode, err)
+}